@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Binary encoding headers of the probabilistic data structures.
+const (
+	bloomMagic    = 'B'
+	countMinMagic = 'C'
+	hllMagic      = 'H'
+	sketchVersion = 1
+)
+
+// Size limits of the probabilistic data structures.
+const (
+	// maxSketchWords is the maximum number of 64-bit words allocated by a sketch (1 GiB).
+	maxSketchWords = 1 << 27
+	// maxBloomHashes is the maximum number of hash functions of a Bloom filter, enough for the
+	// smallest positive false positive rate.
+	maxBloomHashes = 1100
+)
+
+// Bloom is a Bloom filter for approximate set membership. It can report false positives
+// with a configurable probability but never reports false negatives.
+//
+// Source: https://en.wikipedia.org/wiki/Bloom_filter
+type Bloom struct {
+	words []uint64
+	m     uint64 // number of bits
+	k     uint32 // number of hash functions
+}
+
+// NewBloom creates a [Bloom] filter sized for n elements with the given false positive rate,
+// which must be in the (0, 1) range. The bits of the filter must fit in 1 GiB.
+func NewBloom(n uint64, fpRate float64) (*Bloom, error) {
+	if n == 0 {
+		return nil, fmt.Errorf("n=%d: %w", n, ErrInvalidParameter)
+	}
+
+	if math.IsNaN(fpRate) || fpRate <= 0 || fpRate >= 1 {
+		return nil, fmt.Errorf("fpRate=%v: %w", fpRate, ErrInvalidParameter)
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := max(1, math.Round(m/float64(n)*math.Ln2))
+
+	if m > maxSketchWords*64 || k > maxBloomHashes { //nolint:mnd // Bits per word.
+		return nil, fmt.Errorf("bloom m=%v/k=%v: %w", m, k, ErrInvalidParameter)
+	}
+
+	return newBloom(uint64(m), uint32(k)), nil
+}
+
+func newBloom(m uint64, k uint32) *Bloom {
+	words := (m + 63) / 64 //nolint:mnd // Bits per word.
+
+	return &Bloom{words: make([]uint64, words), m: words * 64, k: k} //nolint:mnd // Bits per word.
+}
+
+// Cap returns the number of bits and the number of hash functions used by the filter.
+func (b *Bloom) Cap() (uint64, uint32) {
+	return b.m, b.k
+}
+
+// Add adds data to the filter.
+func (b *Bloom) Add(data []byte) {
+	h1, h2 := hashPair(data)
+	for i := range uint64(b.k) {
+		pos := (h1 + i*h2) % b.m
+		b.words[pos/64] |= 1 << (pos % 64) //nolint:mnd // Bits per word.
+	}
+}
+
+// AddString adds s to the filter.
+func (b *Bloom) AddString(s string) {
+	b.Add([]byte(s))
+}
+
+// Test reports whether data is possibly in the filter. If false, data is definitely not in it.
+func (b *Bloom) Test(data []byte) bool {
+	h1, h2 := hashPair(data)
+	for i := range uint64(b.k) {
+		pos := (h1 + i*h2) % b.m
+		if b.words[pos/64]&(1<<(pos%64)) == 0 { //nolint:mnd // Bits per word.
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestString reports whether s is possibly in the filter. If false, s is definitely not in it.
+func (b *Bloom) TestString(s string) bool {
+	return b.Test([]byte(s))
+}
+
+// FalsePositiveRate estimates the current false positive rate from the fraction of bits set.
+func (b *Bloom) FalsePositiveRate() float64 {
+	var set int
+	for _, w := range b.words {
+		set += bits.OnesCount64(w)
+	}
+
+	return math.Pow(float64(set)/float64(b.m), float64(b.k))
+}
+
+// Merge adds all elements of o into the filter. Both filters must have the same configuration.
+func (b *Bloom) Merge(o *Bloom) error {
+	if b.m != o.m || b.k != o.k {
+		return fmt.Errorf("bloom m=%d/k=%d vs m=%d/k=%d: %w", b.m, b.k, o.m, o.k, ErrIncompatible)
+	}
+
+	for i, w := range o.words {
+		b.words[i] |= w
+	}
+
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] for a Bloom filter.
+// This function never returns errors.
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 14+8*len(b.words)) //nolint:mnd // Header and word sizes.
+	out = append(out, bloomMagic, sketchVersion)
+	out = binary.BigEndian.AppendUint64(out, b.m)
+	out = binary.BigEndian.AppendUint32(out, b.k)
+
+	for _, w := range b.words {
+		out = binary.BigEndian.AppendUint64(out, w)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] for a Bloom filter.
+// It accepts any slice of bytes produced by [Bloom.MarshalBinary].
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	const hdrLen = 14
+
+	if len(data) < hdrLen || data[0] != bloomMagic || data[1] != sketchVersion {
+		return fmt.Errorf("bloom header: %w", ErrUnknownFormat)
+	}
+
+	m := binary.BigEndian.Uint64(data[2:])
+	k := binary.BigEndian.Uint32(data[10:])
+	body := data[hdrLen:]
+
+	if m == 0 || m%64 != 0 || k == 0 || k > maxBloomHashes || uint64(len(body)) != m/8 { //nolint:mnd // Bits per word/byte.
+		return fmt.Errorf("bloom m=%d/k=%d: %w", m, k, ErrUnknownFormat)
+	}
+
+	nb := newBloom(m, k)
+	for i := range nb.words {
+		nb.words[i] = binary.BigEndian.Uint64(body[8*i:]) //nolint:mnd // Bytes per word.
+	}
+
+	*b = *nb
+
+	return nil
+}
+
+// Equal reports whether b and o have the same configuration and contents.
+func (b *Bloom) Equal(o *Bloom) bool {
+	return b.m == o.m && b.k == o.k && slices.Equal(b.words, o.words)
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBloom(t *testing.T) {
+	testCases := []struct {
+		name    string
+		n       uint64
+		fpRate  float64
+		wantErr error
+	}{
+		{name: "Valid", n: 1000, fpRate: 0.01, wantErr: nil},
+		{name: "ZeroN", n: 0, fpRate: 0.01, wantErr: ds.ErrInvalidParameter},
+		{name: "ZeroRate", n: 1000, fpRate: 0, wantErr: ds.ErrInvalidParameter},
+		{name: "OneRate", n: 1000, fpRate: 1, wantErr: ds.ErrInvalidParameter},
+		{name: "NaNRate", n: 1000, fpRate: math.NaN(), wantErr: ds.ErrInvalidParameter},
+		{name: "SmallestRate", n: 1000, fpRate: math.SmallestNonzeroFloat64, wantErr: nil},
+		{name: "TooLarge", n: 1 << 40, fpRate: 0.01, wantErr: ds.ErrInvalidParameter},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			_, err := ds.NewBloom(tCase.n, tCase.fpRate)
+			require.ErrorIs(t, err, tCase.wantErr)
+		})
+	}
+}
+
+func TestBloomAccuracy(t *testing.T) {
+	for _, fpRate := range []float64{0.1, 0.01, 0.001} {
+		t.Run(strconv.FormatFloat(fpRate, 'g', -1, 64), func(t *testing.T) {
+			const n, probes = 10000, 100000
+
+			b, err := ds.NewBloom(n, fpRate)
+			require.NoError(t, err)
+
+			for i := range n {
+				b.AddString("member-" + strconv.Itoa(i))
+			}
+
+			for i := range n {
+				require.True(t, b.TestString("member-"+strconv.Itoa(i)), "false negative")
+			}
+
+			var fps int
+			for i := range probes {
+				if b.TestString("probe-" + strconv.Itoa(i)) {
+					fps++
+				}
+			}
+
+			assert.LessOrEqual(t, float64(fps)/probes, 1.5*fpRate)
+			assert.InDelta(t, fpRate, b.FalsePositiveRate(), fpRate/2)
+		})
+	}
+}
+
+func TestBloomMerge(t *testing.T) {
+	a, err := ds.NewBloom(100, 0.01)
+	require.NoError(t, err)
+	b, err := ds.NewBloom(100, 0.01)
+	require.NoError(t, err)
+
+	a.AddString("foo")
+	b.AddString("bar")
+	require.NoError(t, a.Merge(b))
+	assert.True(t, a.TestString("foo"))
+	assert.True(t, a.TestString("bar"))
+
+	c, err := ds.NewBloom(1000, 0.01)
+	require.NoError(t, err)
+	require.ErrorIs(t, a.Merge(c), ds.ErrIncompatible)
+}
+
+func TestBloomBinary(t *testing.T) {
+	b, err := ds.NewBloom(100, 0.01)
+	require.NoError(t, err)
+	b.AddString("foo")
+
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+
+	var got ds.Bloom
+
+	require.NoError(t, got.UnmarshalBinary(data))
+	assert.True(t, got.Equal(b))
+	assert.True(t, got.TestString("foo"))
+
+	require.ErrorIs(t, got.UnmarshalBinary(data[:10]), ds.ErrUnknownFormat)
+	require.ErrorIs(t, got.UnmarshalBinary(data[:len(data)-1]), ds.ErrUnknownFormat)
+	require.ErrorIs(t, got.UnmarshalBinary(append([]byte{'X'}, data[1:]...)), ds.ErrUnknownFormat)
+
+	tooManyHashes := slices.Clone(data)
+	copy(tooManyHashes[10:], []byte{0xff, 0xff, 0xff, 0xff})
+	require.ErrorIs(t, got.UnmarshalBinary(tooManyHashes), ds.ErrUnknownFormat)
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CountMin is a Count-Min sketch for approximate frequency counting. Estimated counts are never
+// lower than the true counts and, with probability 1-delta, exceed them by at most epsilon times
+// the total count of all elements.
+//
+// Source: https://en.wikipedia.org/wiki/Count%E2%80%93min_sketch
+type CountMin struct {
+	counts []uint64 // depth rows of width counters
+	width  uint32
+	depth  uint32
+	total  uint64
+}
+
+// NewCountMin creates a [CountMin] sketch with the given error factor epsilon and error
+// probability delta, both of which must be in the (0, 1) range. The counters of the sketch must
+// fit in 1 GiB.
+func NewCountMin(epsilon, delta float64) (*CountMin, error) {
+	if math.IsNaN(epsilon) || epsilon <= 0 || epsilon >= 1 {
+		return nil, fmt.Errorf("epsilon=%v: %w", epsilon, ErrInvalidParameter)
+	}
+
+	if math.IsNaN(delta) || delta <= 0 || delta >= 1 {
+		return nil, fmt.Errorf("delta=%v: %w", delta, ErrInvalidParameter)
+	}
+
+	width := math.Ceil(math.E / epsilon)
+	depth := math.Ceil(math.Log(1 / delta))
+
+	if width*depth > maxSketchWords {
+		return nil, fmt.Errorf("count-min width=%v/depth=%v: %w", width, depth, ErrInvalidParameter)
+	}
+
+	return newCountMin(uint32(width), uint32(depth)), nil
+}
+
+func newCountMin(width, depth uint32) *CountMin {
+	return &CountMin{
+		counts: make([]uint64, uint64(width)*uint64(depth)),
+		width:  width,
+		depth:  depth,
+		total:  0,
+	}
+}
+
+// Cap returns the width and depth of the sketch.
+func (c *CountMin) Cap() (uint32, uint32) {
+	return c.width, c.depth
+}
+
+// Add increments the count of data by n.
+func (c *CountMin) Add(data []byte, n uint64) {
+	h1, h2 := hashPair(data)
+	for i := range uint64(c.depth) {
+		c.counts[i*uint64(c.width)+(h1+i*h2)%uint64(c.width)] += n
+	}
+
+	c.total += n
+}
+
+// AddString increments the count of s by n.
+func (c *CountMin) AddString(s string, n uint64) {
+	c.Add([]byte(s), n)
+}
+
+// Count returns the estimated count of data.
+func (c *CountMin) Count(data []byte) uint64 {
+	h1, h2 := hashPair(data)
+
+	est := uint64(math.MaxUint64)
+	for i := range uint64(c.depth) {
+		est = min(est, c.counts[i*uint64(c.width)+(h1+i*h2)%uint64(c.width)])
+	}
+
+	return est
+}
+
+// CountString returns the estimated count of s.
+func (c *CountMin) CountString(s string) uint64 {
+	return c.Count([]byte(s))
+}
+
+// Total returns the total count of all elements added to the sketch.
+func (c *CountMin) Total() uint64 {
+	return c.total
+}
+
+// Merge adds all counts of o into the sketch. Both sketches must have the same configuration.
+func (c *CountMin) Merge(o *CountMin) error {
+	if c.width != o.width || c.depth != o.depth {
+		return fmt.Errorf(
+			"count-min width=%d/depth=%d vs width=%d/depth=%d: %w",
+			c.width, c.depth, o.width, o.depth, ErrIncompatible,
+		)
+	}
+
+	for i, n := range o.counts {
+		c.counts[i] += n
+	}
+
+	c.total += o.total
+
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] for a Count-Min sketch.
+// This function never returns errors.
+func (c *CountMin) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 18+8*len(c.counts)) //nolint:mnd // Header and counter sizes.
+	out = append(out, countMinMagic, sketchVersion)
+	out = binary.BigEndian.AppendUint32(out, c.width)
+	out = binary.BigEndian.AppendUint32(out, c.depth)
+	out = binary.BigEndian.AppendUint64(out, c.total)
+
+	for _, n := range c.counts {
+		out = binary.BigEndian.AppendUint64(out, n)
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] for a Count-Min sketch.
+// It accepts any slice of bytes produced by [CountMin.MarshalBinary].
+func (c *CountMin) UnmarshalBinary(data []byte) error {
+	const hdrLen = 18
+
+	if len(data) < hdrLen || data[0] != countMinMagic || data[1] != sketchVersion {
+		return fmt.Errorf("count-min header: %w", ErrUnknownFormat)
+	}
+
+	width := binary.BigEndian.Uint32(data[2:])
+	depth := binary.BigEndian.Uint32(data[6:])
+	total := binary.BigEndian.Uint64(data[10:])
+	body := data[hdrLen:]
+
+	// The number of counters is checked by division to not overflow with malformed headers.
+	n := uint64(len(body) / 8) //nolint:mnd // Counter size.
+	if width == 0 || depth == 0 || len(body)%8 != 0 || n%uint64(width) != 0 || n/uint64(width) != uint64(depth) {
+		return fmt.Errorf("count-min width=%d/depth=%d: %w", width, depth, ErrUnknownFormat)
+	}
+
+	nc := newCountMin(width, depth)
+	for i := range nc.counts {
+		nc.counts[i] = binary.BigEndian.Uint64(body[8*i:]) //nolint:mnd // Counter size.
+	}
+
+	nc.total = total
+	*c = *nc
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCountMin(t *testing.T) {
+	testCases := []struct {
+		name    string
+		epsilon float64
+		delta   float64
+		wantErr error
+	}{
+		{name: "Valid", epsilon: 0.01, delta: 0.01, wantErr: nil},
+		{name: "InvalidEpsilon", epsilon: 0, delta: 0.01, wantErr: ds.ErrInvalidParameter},
+		{name: "InvalidDelta", epsilon: 0.01, delta: 1, wantErr: ds.ErrInvalidParameter},
+		{name: "NaNEpsilon", epsilon: math.NaN(), delta: 0.01, wantErr: ds.ErrInvalidParameter},
+		{name: "TooLarge", epsilon: 1e-12, delta: 0.01, wantErr: ds.ErrInvalidParameter},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			_, err := ds.NewCountMin(tCase.epsilon, tCase.delta)
+			require.ErrorIs(t, err, tCase.wantErr)
+		})
+	}
+}
+
+func TestCountMinAccuracy(t *testing.T) {
+	const (
+		epsilon = 0.001
+		delta   = 0.01
+		keys    = 5000
+	)
+
+	c, err := ds.NewCountMin(epsilon, delta)
+	require.NoError(t, err)
+
+	rnd := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // Not security sensitive.
+	truth := make(map[string]uint64, keys)
+
+	for range 200000 {
+		// Zipf-like skew: low keys are much more frequent.
+		k := "key-" + strconv.Itoa(int(float64(keys)*rnd.Float64()*rnd.Float64()))
+		truth[k]++
+		c.AddString(k, 1)
+	}
+
+	bound := uint64(epsilon * float64(c.Total()))
+
+	var exceeded int
+
+	for k, n := range truth {
+		est := c.CountString(k)
+		require.GreaterOrEqual(t, est, n, "underestimate")
+
+		if est-n > bound {
+			exceeded++
+		}
+	}
+
+	assert.LessOrEqual(t, float64(exceeded)/float64(len(truth)), delta)
+}
+
+func TestCountMinMerge(t *testing.T) {
+	a, err := ds.NewCountMin(0.01, 0.01)
+	require.NoError(t, err)
+	b, err := ds.NewCountMin(0.01, 0.01)
+	require.NoError(t, err)
+
+	a.AddString("foo", 3)
+	b.AddString("foo", 4)
+	b.AddString("bar", 1)
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(7), a.CountString("foo"))
+	assert.Equal(t, uint64(8), a.Total())
+
+	c, err := ds.NewCountMin(0.1, 0.01)
+	require.NoError(t, err)
+	require.ErrorIs(t, a.Merge(c), ds.ErrIncompatible)
+}
+
+func TestCountMinBinary(t *testing.T) {
+	c, err := ds.NewCountMin(0.01, 0.01)
+	require.NoError(t, err)
+	c.AddString("foo", 42)
+
+	data, err := c.MarshalBinary()
+	require.NoError(t, err)
+
+	var got ds.CountMin
+
+	require.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, uint64(42), got.CountString("foo"))
+	assert.Equal(t, uint64(42), got.Total())
+	assert.Equal(t, *c, got)
+
+	require.ErrorIs(t, got.UnmarshalBinary(data[:5]), ds.ErrUnknownFormat)
+	require.ErrorIs(t, got.UnmarshalBinary(data[:len(data)-1]), ds.ErrUnknownFormat)
+}
+
+func TestCountMinBinaryMalformed(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "OverflowingSize",
+			data: []byte{'C', 1, 0x80, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "MaxSize",
+			data: []byte{'C', 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "ZeroWidth",
+			data: []byte{'C', 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "SizeMismatch",
+			data: []byte{'C', 1, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "PartialCounter",
+			data: []byte{'C', 1, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var got ds.CountMin
+
+			require.ErrorIs(t, got.UnmarshalBinary(tCase.data), ds.ErrUnknownFormat)
+		})
+	}
+}
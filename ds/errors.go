@@ -9,4 +9,10 @@ import "errors"
 var (
 	// ErrUnknownFormat is returned when an unknown format is used.
 	ErrUnknownFormat = errors.New("unknown format")
+	// ErrUnsupportedType is returned when a type cannot be marshaled/unmarshaled as text.
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrInvalidParameter is returned when a data structure is created with an invalid parameter.
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrIncompatible is returned when merging data structures with different configurations.
+	ErrIncompatible = errors.New("incompatible data structures")
 )
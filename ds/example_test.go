@@ -123,3 +123,81 @@ func ExampleBareRanges_UnmarshalText() {
 	// Output:
 	// [{-9223372036854775808 10 {}} {20 30 {}} {40 9223372036854775807 {}} {50 50 {}} {-9223372036854775808 9223372036854775807 {}}]
 }
+
+func ExampleSet_Union() {
+	a := ds.NewSet("cat", "dog")
+	b := ds.NewSet("dog", "fox")
+
+	fmt.Println(a.Union(b).Len(), a.Intersect(b).Contains("dog"))
+	// Output:
+	// 3 true
+}
+
+func ExampleOrderedSet_All() {
+	s := ds.NewOrderedSet("fox", "cat", "dog")
+
+	for e := range s.All() {
+		fmt.Println(e)
+	}
+	// Output:
+	// cat
+	// dog
+	// fox
+}
+
+func ExampleOrderedSet_MarshalText() {
+	s := ds.NewOrderedSet(50, 10, 30)
+
+	b, err := s.MarshalText()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(b))
+	// Output:
+	// 10,30,50
+}
+
+func ExampleRadix_LongestPrefix() {
+	routes := ds.NewRadix[string]()
+	routes.Insert("/", "index")
+	routes.Insert("/api", "api")
+	routes.Insert("/api/users", "users")
+
+	for _, path := range []string{"/api/users/42", "/api/groups", "/about"} {
+		key, value, _ := routes.LongestPrefix(path)
+		fmt.Printf("%s matches %s (%s)\n", path, key, value)
+	}
+	// Output:
+	// /api/users/42 matches /api/users (users)
+	// /api/groups matches /api (api)
+	// /about matches / (index)
+}
+
+func ExampleSortedMap_Scan() {
+	m := ds.NewSortedMap[int, string]()
+	m.Set(30, "fox")
+	m.Set(10, "cat")
+	m.Set(20, "dog")
+	m.Set(40, "owl")
+
+	for k, v := range m.Scan(15, 35) {
+		fmt.Println(k, v)
+	}
+	// Output:
+	// 20 dog
+	// 30 fox
+}
+
+func ExampleRanges_Insert() {
+	ranges := ds.Ranges{
+		{Min: 1, Max: 2, Value: "dog"},
+		{Min: 4, Max: 4, Value: "cat"},
+	}
+
+	ranges = ranges.Insert(ds.Range{Min: 3, Max: 8, Value: "fox"})
+
+	fmt.Println(ranges)
+	// Output:
+	// [{1 2 dog} {3 8 fox} {4 4 cat}]
+}
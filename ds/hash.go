@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+// FNV-1a 64-bit parameters.
+//
+// Source: http://www.isthe.com/chongo/tech/comp/fnv/
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// hash64 returns a 64-bit hash of data for the given seed. Unlike [hash/maphash], the result is
+// stable across processes and platforms, so it can be used in serialized data structures.
+func hash64(data []byte, seed uint64) uint64 {
+	h := uint64(fnvOffset64) ^ mix64(seed)
+	for _, c := range data {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+
+	return mix64(h)
+}
+
+// hashPair returns two independent-looking 64-bit hashes of data for double hashing.
+// The second hash is always odd so that it can generate full cycles modulo powers of two.
+//
+// Source: https://doi.org/10.1002/rsa.20208
+func hashPair(data []byte) (uint64, uint64) {
+	h1 := hash64(data, 0)
+	h2 := mix64(h1^0x9e3779b97f4a7c15) | 1 //nolint:mnd // Golden ratio constant.
+
+	return h1, h2
+}
+
+// mix64 is the finalizer of the SplitMix64 generator, a good 64-bit bit mixer.
+//
+// Source: https://prng.di.unimi.it/splitmix64.c
+func mix64(x uint64) uint64 {
+	x ^= x >> 30 //nolint:mnd // Algorithm constant.
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27 //nolint:mnd // Algorithm constant.
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31 //nolint:mnd // Algorithm constant.
+
+	return x
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// HashRing is a consistent hash ring that maps keys to nodes. Each node is placed on the ring
+// as a number of virtual nodes proportional to its weight, so adding or removing a node only
+// moves the keys of that node. It also supports consistent hashing with bounded loads through
+// [HashRing.Acquire] and [HashRing.Release]. A HashRing is safe for concurrent use.
+//
+// Source: https://en.wikipedia.org/wiki/Consistent_hashing
+type HashRing struct {
+	mu         sync.RWMutex
+	replicas   int
+	loadFactor float64
+	weights    map[string]int
+	loads      map[string]int
+	points     []ringPoint // sorted by hash
+	totalLoad  int
+}
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// NewHashRing creates an empty [HashRing] with replicas virtual nodes per unit of node weight.
+// The loadFactor (at least 1) bounds the load of each node in [HashRing.Acquire] to loadFactor
+// times its fair share of the total load.
+//
+// Source: https://arxiv.org/abs/1608.01350
+func NewHashRing(replicas int, loadFactor float64) (*HashRing, error) {
+	if replicas < 1 {
+		return nil, fmt.Errorf("replicas=%d: %w", replicas, ErrInvalidParameter)
+	}
+
+	if loadFactor < 1 {
+		return nil, fmt.Errorf("loadFactor=%v: %w", loadFactor, ErrInvalidParameter)
+	}
+
+	return &HashRing{ //nolint:exhaustruct_v5 // Zero values are ready to use.
+		replicas:   replicas,
+		loadFactor: loadFactor,
+		weights:    map[string]int{},
+		loads:      map[string]int{},
+	}, nil
+}
+
+// Add adds node to the ring with the given weight, which must be positive.
+// If node is already in the ring, its weight is updated.
+func (r *HashRing) Add(node string, weight int) error {
+	if weight < 1 {
+		return fmt.Errorf("weight=%d: %w", weight, ErrInvalidParameter)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.weights[node] = weight
+	r.rebuild()
+
+	return nil
+}
+
+// Remove removes node from the ring. It reports whether the node was present.
+func (r *HashRing) Remove(node string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.weights[node]; !ok {
+		return false
+	}
+
+	delete(r.weights, node)
+	r.totalLoad -= r.loads[node]
+	delete(r.loads, node)
+	r.rebuild()
+
+	return true
+}
+
+// Nodes returns the nodes of the ring in ascending order.
+func (r *HashRing) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	nodes := make([]string, 0, len(r.weights))
+	for n := range r.weights {
+		nodes = append(nodes, n)
+	}
+
+	slices.Sort(nodes)
+
+	return nodes
+}
+
+// Get returns the node that owns key and whether the ring has any nodes.
+func (r *HashRing) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return "", false
+	}
+
+	return r.points[r.search(hash64([]byte(key), 0))].node, true
+}
+
+// Acquire returns the node for key using consistent hashing with bounded loads and increments
+// the load of that node. Keys are assigned to the first node clockwise from their position that
+// is below its load capacity. It reports false if the ring has no nodes.
+// Each successful call must be paired with a call to [HashRing.Release].
+func (r *HashRing) Acquire(key string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.points) == 0 {
+		return "", false
+	}
+
+	totalWeight := 0
+	for _, w := range r.weights {
+		totalWeight += w
+	}
+
+	start := r.search(hash64([]byte(key), 0))
+	node := r.points[start].node
+
+	for i := range r.points {
+		n := r.points[(start+i)%len(r.points)].node
+		share := float64(r.totalLoad+1) * float64(r.weights[n]) / float64(totalWeight)
+
+		if float64(r.loads[n]+1) <= math.Ceil(r.loadFactor*share) {
+			node = n
+
+			break
+		}
+	}
+
+	r.loads[node]++
+	r.totalLoad++
+
+	return node, true
+}
+
+// Release decrements the load of node previously returned by [HashRing.Acquire].
+func (r *HashRing) Release(node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.loads[node] > 0 {
+		r.loads[node]--
+		r.totalLoad--
+	}
+}
+
+// Load returns the current load of node as tracked by [HashRing.Acquire] and [HashRing.Release].
+func (r *HashRing) Load(node string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.loads[node]
+}
+
+// Point returns the position of key in the ring key space as used by [HashRing.Ranges].
+func (r *HashRing) Point(key string) int {
+	return ringPosition(hash64([]byte(key), 0))
+}
+
+// Ranges exports the partitioning of the ring key space as sorted [Ranges], where each [Range]
+// references the node (string) that owns it. Adjacent ranges of the same node are merged.
+// Searching the result with [HashRing.Point] of a key gives the same node as [HashRing.Get].
+func (r *HashRing) Ranges() Ranges {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return Ranges{}
+	}
+
+	out := Ranges{}
+	appendRange := func(rmin, rmax int, node string) {
+		if last := len(out) - 1; last >= 0 && out[last].Value == node && out[last].Max+1 == rmin {
+			out[last].Max = rmax
+
+			return
+		}
+
+		out = append(out, Range{Min: rmin, Max: rmax, Value: node})
+	}
+
+	// The first point also owns the wrap-around segment after the last point.
+	first, last := r.points[0], r.points[len(r.points)-1]
+	appendRange(RangeMin, ringPosition(first.hash), first.node)
+
+	for i := 1; i < len(r.points); i++ {
+		prev, cur := r.points[i-1], r.points[i]
+		if prev.hash != cur.hash {
+			appendRange(ringPosition(prev.hash)+1, ringPosition(cur.hash), cur.node)
+		}
+	}
+
+	if last.hash != math.MaxUint64 {
+		appendRange(ringPosition(last.hash)+1, RangeMax, first.node)
+	}
+
+	return out
+}
+
+func (r *HashRing) rebuild() {
+	r.points = r.points[:0]
+
+	for node, weight := range r.weights {
+		for i := range weight * r.replicas {
+			h := hash64([]byte(node+"#"+strconv.Itoa(i)), 0)
+			r.points = append(r.points, ringPoint{hash: h, node: node})
+		}
+	}
+
+	slices.SortFunc(r.points, func(a, b ringPoint) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.node, b.node))
+	})
+}
+
+// search returns the index of the first point clockwise from hash h.
+func (r *HashRing) search(h uint64) int {
+	idx, _ := slices.BinarySearchFunc(r.points, h, func(p ringPoint, h uint64) int {
+		return cmp.Compare(p.hash, h)
+	})
+	if idx == len(r.points) {
+		idx = 0
+	}
+
+	return idx
+}
+
+// ringPosition maps a hash to an int preserving its order.
+func ringPosition(h uint64) int {
+	return int(int64(h ^ 1<<63)) //nolint:gosec,mnd // Intended two's complement offset.
+}
+
+// JumpHash maps key to a bucket in the [0, buckets) range using the Jump consistent hash
+// algorithm, which needs no memory and moves only 1/buckets of the keys when adding a bucket.
+// It returns -1 if buckets is less than one.
+//
+// Source: https://arxiv.org/abs/1406.2294
+func JumpHash(key string, buckets int) int {
+	h := hash64([]byte(key), 0)
+
+	b, j := -1, 0
+	for j < buckets {
+		b = j
+		h = h*2862933555777941757 + 1                                 //nolint:mnd // Algorithm constant.
+		j = int(float64(b+1) * (float64(1<<31) / float64((h>>33)+1))) //nolint:mnd // Algorithm constant.
+	}
+
+	return b
+}
+
+// Rendezvous returns the node with the highest random weight for key using weighted Rendezvous
+// (highest random weight) hashing. Removing a node only moves the keys of that node.
+// Nodes with non-positive weights are ignored. It reports false if there are no eligible nodes.
+//
+// Source: https://en.wikipedia.org/wiki/Rendezvous_hashing
+func Rendezvous(key string, weights map[string]int) (string, bool) {
+	var (
+		best      string
+		bestScore = math.Inf(-1)
+		found     bool
+	)
+
+	for node, weight := range weights {
+		if weight < 1 {
+			continue
+		}
+
+		// Uniform value in (0, 1) from the top 53 bits of the hash.
+		u := (float64(hash64([]byte(key), hash64([]byte(node), 0))>>11) + 0.5) / (1 << 53) //nolint:mnd // Float bits.
+		score := float64(weight) / -math.Log(u)
+
+		if score > bestScore || (score == bestScore && node < best) {
+			best, bestScore, found = node, score, true
+		}
+	}
+
+	return best, found
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHashRing(t *testing.T) {
+	testCases := []struct {
+		name       string
+		replicas   int
+		loadFactor float64
+		wantErr    error
+	}{
+		{name: "Valid", replicas: 10, loadFactor: 1.25, wantErr: nil},
+		{name: "InvalidReplicas", replicas: 0, loadFactor: 1.25, wantErr: ds.ErrInvalidParameter},
+		{name: "InvalidLoadFactor", replicas: 10, loadFactor: 0.5, wantErr: ds.ErrInvalidParameter},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			_, err := ds.NewHashRing(tCase.replicas, tCase.loadFactor)
+			require.ErrorIs(t, err, tCase.wantErr)
+		})
+	}
+}
+
+func TestHashRingAddRemove(t *testing.T) {
+	r, err := ds.NewHashRing(100, 1.25)
+	require.NoError(t, err)
+
+	_, ok := r.Get("foo")
+	assert.False(t, ok)
+	require.ErrorIs(t, r.Add("a", 0), ds.ErrInvalidParameter)
+
+	for _, n := range []string{"a", "b", "c"} {
+		require.NoError(t, r.Add(n, 1))
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, r.Nodes())
+
+	const keys = 10000
+
+	before := make([]string, keys)
+	for i := range keys {
+		before[i], _ = r.Get(strconv.Itoa(i))
+	}
+
+	require.NoError(t, r.Add("d", 1))
+
+	var moved int
+
+	for i := range keys {
+		after, _ := r.Get(strconv.Itoa(i))
+		if after != before[i] {
+			assert.Equal(t, "d", after, "keys must only move to the new node")
+
+			moved++
+		}
+	}
+
+	assert.InDelta(t, keys/4, moved, keys/10)
+
+	assert.True(t, r.Remove("d"))
+	assert.False(t, r.Remove("d"))
+
+	for i := range keys {
+		after, _ := r.Get(strconv.Itoa(i))
+		assert.Equal(t, before[i], after)
+	}
+}
+
+func TestHashRingWeights(t *testing.T) {
+	r, err := ds.NewHashRing(100, 1.25)
+	require.NoError(t, err)
+	require.NoError(t, r.Add("a", 1))
+	require.NoError(t, r.Add("b", 3))
+
+	counts := map[string]int{}
+	for i := range 10000 {
+		n, _ := r.Get(strconv.Itoa(i))
+		counts[n]++
+	}
+
+	assert.InDelta(t, 7500, counts["b"], 750)
+}
+
+func TestHashRingAcquire(t *testing.T) {
+	r, err := ds.NewHashRing(100, 1.25)
+	require.NoError(t, err)
+
+	_, ok := r.Acquire("foo")
+	assert.False(t, ok)
+
+	for _, n := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, r.Add(n, 1))
+	}
+
+	const keys = 1000
+
+	for i := range keys {
+		_, ok := r.Acquire(strconv.Itoa(i))
+		require.True(t, ok)
+	}
+
+	for _, n := range r.Nodes() {
+		assert.LessOrEqual(t, r.Load(n), 313) // ceil(1.25 * 1000 / 4)
+	}
+
+	r.Release("a")
+	r.Release("unknown")
+}
+
+func TestHashRingRanges(t *testing.T) {
+	r, err := ds.NewHashRing(100, 1.25)
+	require.NoError(t, err)
+	assert.Equal(t, ds.Ranges{}, r.Ranges())
+
+	for _, n := range []string{"a", "b", "c"} {
+		require.NoError(t, r.Add(n, 1))
+	}
+
+	rngs := r.Ranges()
+
+	require.NotEmpty(t, rngs)
+	assert.Equal(t, ds.RangeMin, rngs[0].Min)
+	assert.Equal(t, ds.RangeMax, rngs[len(rngs)-1].Max)
+
+	for i := 1; i < len(rngs); i++ {
+		assert.Equal(t, rngs[i-1].Max+1, rngs[i].Min, "ranges must be contiguous")
+	}
+
+	for i := range 1000 {
+		k := strconv.Itoa(i)
+		want, _ := r.Get(k)
+		assert.Equal(t, want, rngs.Search(r.Point(k)))
+	}
+}
+
+func TestJumpHash(t *testing.T) {
+	assert.Equal(t, -1, ds.JumpHash("foo", 0))
+	assert.Equal(t, 0, ds.JumpHash("foo", 1))
+
+	const keys = 10000
+
+	var moved int
+
+	counts := make([]int, 11)
+
+	for i := range keys {
+		k := strconv.Itoa(i)
+		b10, b11 := ds.JumpHash(k, 10), ds.JumpHash(k, 11)
+
+		counts[b11]++
+
+		if b10 != b11 {
+			assert.Equal(t, 10, b11, "keys must only move to the new bucket")
+
+			moved++
+		}
+	}
+
+	assert.InDelta(t, keys/11, moved, keys/50)
+
+	for _, c := range counts {
+		assert.InDelta(t, keys/11, c, keys/50)
+	}
+}
+
+func TestRendezvous(t *testing.T) {
+	_, ok := ds.Rendezvous("foo", map[string]int{"a": 0})
+	assert.False(t, ok)
+
+	weights := map[string]int{"a": 1, "b": 1, "c": 2}
+
+	const keys = 10000
+
+	before := make([]string, keys)
+	counts := map[string]int{}
+
+	for i := range keys {
+		before[i], _ = ds.Rendezvous(strconv.Itoa(i), weights)
+		counts[before[i]]++
+	}
+
+	assert.InDelta(t, keys/2, counts["c"], keys/20)
+
+	delete(weights, "a")
+
+	for i := range keys {
+		after, _ := ds.Rendezvous(strconv.Itoa(i), weights)
+		if before[i] != "a" {
+			assert.Equal(t, before[i], after)
+		}
+	}
+}
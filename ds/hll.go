@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Minimum and maximum precisions (number of register index bits) of an [HLL] sketch.
+const (
+	HLLMinPrecision = 4
+	HLLMaxPrecision = 18
+)
+
+// HLL is a HyperLogLog sketch for approximate cardinality (distinct count) estimation.
+// Its relative standard error is about 1.04/sqrt(2^precision).
+//
+// Source: https://en.wikipedia.org/wiki/HyperLogLog
+type HLL struct {
+	registers []uint8
+	precision uint8
+}
+
+// NewHLL creates an [HLL] sketch with the smallest precision that achieves the given relative
+// standard error. The precision is clamped to the [HLLMinPrecision, HLLMaxPrecision] range.
+func NewHLL(stdErr float64) (*HLL, error) {
+	if stdErr <= 0 || stdErr >= 1 {
+		return nil, fmt.Errorf("stdErr=%v: %w", stdErr, ErrInvalidParameter)
+	}
+
+	p := math.Ceil(math.Log2(math.Pow(1.04/stdErr, 2))) //nolint:mnd // Algorithm constant.
+	p = min(max(p, HLLMinPrecision), HLLMaxPrecision)
+
+	return newHLL(uint8(p)), nil
+}
+
+func newHLL(precision uint8) *HLL {
+	return &HLL{registers: make([]uint8, 1<<precision), precision: precision}
+}
+
+// Precision returns the number of register index bits of the sketch.
+func (h *HLL) Precision() uint8 {
+	return h.precision
+}
+
+// Add adds data to the sketch.
+func (h *HLL) Add(data []byte) {
+	x := hash64(data, 0)
+	idx := x >> (64 - h.precision)                                            //nolint:mnd // Hash size.
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1)) + 1) //nolint:gosec // At most 64.
+
+	h.registers[idx] = max(h.registers[idx], rank)
+}
+
+// AddString adds s to the sketch.
+func (h *HLL) AddString(s string) {
+	h.Add([]byte(s))
+}
+
+// Count returns the estimated number of distinct elements added to the sketch.
+func (h *HLL) Count() uint64 {
+	m := float64(len(h.registers))
+
+	var (
+		sum   float64
+		zeros int
+	)
+
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	est := hllAlpha(len(h.registers)) * m * m / sum
+
+	// Small range correction using linear counting.
+	if est <= 2.5*m && zeros > 0 { //nolint:mnd // Algorithm constant.
+		est = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(est + 0.5) //nolint:mnd // Rounding.
+}
+
+// Merge adds all elements of o into the sketch. Both sketches must have the same precision.
+func (h *HLL) Merge(o *HLL) error {
+	if h.precision != o.precision {
+		return fmt.Errorf("hll precision=%d vs precision=%d: %w", h.precision, o.precision, ErrIncompatible)
+	}
+
+	for i, r := range o.registers {
+		h.registers[i] = max(h.registers[i], r)
+	}
+
+	return nil
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler] for a HyperLogLog sketch.
+// This function never returns errors.
+func (h *HLL) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 0, 3+len(h.registers)) //nolint:mnd // Header size.
+	out = append(out, hllMagic, sketchVersion, h.precision)
+	out = append(out, h.registers...)
+
+	return out, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler] for a HyperLogLog sketch.
+// It accepts any slice of bytes produced by [HLL.MarshalBinary].
+func (h *HLL) UnmarshalBinary(data []byte) error {
+	const hdrLen = 3
+
+	if len(data) < hdrLen || data[0] != hllMagic || data[1] != sketchVersion {
+		return fmt.Errorf("hll header: %w", ErrUnknownFormat)
+	}
+
+	p := data[2]
+	if p < HLLMinPrecision || p > HLLMaxPrecision || len(data)-hdrLen != 1<<p {
+		return fmt.Errorf("hll precision=%d: %w", p, ErrUnknownFormat)
+	}
+
+	nh := newHLL(p)
+	copy(nh.registers, data[hdrLen:])
+	*h = *nh
+
+	return nil
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16: //nolint:mnd // Algorithm constant.
+		return 0.673 //nolint:mnd // Algorithm constant.
+	case 32: //nolint:mnd // Algorithm constant.
+		return 0.697 //nolint:mnd // Algorithm constant.
+	case 64: //nolint:mnd // Algorithm constant.
+		return 0.709 //nolint:mnd // Algorithm constant.
+	default:
+		return 0.7213 / (1 + 1.079/float64(m)) //nolint:mnd // Algorithm constant.
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHLL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		stdErr        float64
+		wantPrecision uint8
+		wantErr       error
+	}{
+		{name: "TwoPercent", stdErr: 0.02, wantPrecision: 12, wantErr: nil},
+		{name: "ClampedMin", stdErr: 0.5, wantPrecision: ds.HLLMinPrecision, wantErr: nil},
+		{name: "ClampedMax", stdErr: 0.0001, wantPrecision: ds.HLLMaxPrecision, wantErr: nil},
+		{name: "Invalid", stdErr: 0, wantPrecision: 0, wantErr: ds.ErrInvalidParameter},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			h, err := ds.NewHLL(tCase.stdErr)
+			require.ErrorIs(t, err, tCase.wantErr)
+
+			if tCase.wantErr == nil {
+				assert.Equal(t, tCase.wantPrecision, h.Precision())
+			}
+		})
+	}
+}
+
+func TestHLLAccuracy(t *testing.T) {
+	const stdErr = 0.01
+
+	for _, n := range []int{100, 10000, 500000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			h, err := ds.NewHLL(stdErr)
+			require.NoError(t, err)
+
+			for i := range n {
+				h.AddString("elem-" + strconv.Itoa(i))
+				h.AddString("elem-" + strconv.Itoa(i)) // duplicates must not count
+			}
+
+			relErr := math.Abs(float64(h.Count())-float64(n)) / float64(n)
+			assert.LessOrEqual(t, relErr, 3*stdErr)
+		})
+	}
+}
+
+func TestHLLMerge(t *testing.T) {
+	a, err := ds.NewHLL(0.02)
+	require.NoError(t, err)
+	b, err := ds.NewHLL(0.02)
+	require.NoError(t, err)
+
+	for i := range 20000 {
+		a.AddString(strconv.Itoa(i))
+		b.AddString(strconv.Itoa(i + 10000))
+	}
+
+	require.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 30000, float64(a.Count()), 0.06)
+
+	c, err := ds.NewHLL(0.1)
+	require.NoError(t, err)
+	require.ErrorIs(t, a.Merge(c), ds.ErrIncompatible)
+}
+
+func TestHLLBinary(t *testing.T) {
+	h, err := ds.NewHLL(0.05)
+	require.NoError(t, err)
+
+	for i := range 1000 {
+		h.AddString(strconv.Itoa(i))
+	}
+
+	data, err := h.MarshalBinary()
+	require.NoError(t, err)
+
+	var got ds.HLL
+
+	require.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, h.Count(), got.Count())
+
+	require.ErrorIs(t, got.UnmarshalBinary(data[:2]), ds.ErrUnknownFormat)
+	require.ErrorIs(t, got.UnmarshalBinary(data[:len(data)-1]), ds.ErrUnknownFormat)
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// OrderedSet is a collection of unique ordered elements backed by a sorted slice.
+// Its elements are always iterated in ascending order. The zero value is an empty set ready to use.
+type OrderedSet[T cmp.Ordered] struct { //nolint:recvcheck // Marshalers need value receivers.
+	elems []T
+}
+
+// NewOrderedSet creates an [OrderedSet] containing the given elements.
+func NewOrderedSet[T cmp.Ordered](elems ...T) *OrderedSet[T] {
+	sorted := slices.Clone(elems)
+	slices.Sort(sorted)
+
+	return &OrderedSet[T]{elems: slices.Compact(sorted)}
+}
+
+// Add adds the given elements to the set.
+func (s *OrderedSet[T]) Add(elems ...T) {
+	for _, e := range elems {
+		if i, found := slices.BinarySearch(s.elems, e); !found {
+			s.elems = slices.Insert(s.elems, i, e)
+		}
+	}
+}
+
+// Remove removes the given elements from the set.
+func (s *OrderedSet[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		if i, found := slices.BinarySearch(s.elems, e); found {
+			s.elems = slices.Delete(s.elems, i, i+1)
+		}
+	}
+}
+
+// Contains reports whether e is an element of the set.
+func (s *OrderedSet[T]) Contains(e T) bool {
+	_, found := slices.BinarySearch(s.elems, e)
+
+	return found
+}
+
+// Len is the number of elements in the set.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.elems)
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return slices.Values(s.elems)
+}
+
+// Clone returns a copy of the set.
+func (s *OrderedSet[T]) Clone() *OrderedSet[T] {
+	return &OrderedSet[T]{elems: slices.Clone(s.elems)}
+}
+
+// Union returns a new set with the elements that are in s or in o.
+func (s *OrderedSet[T]) Union(o *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(o, true, true, true)
+}
+
+// Intersect returns a new set with the elements that are in both s and o.
+func (s *OrderedSet[T]) Intersect(o *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(o, false, false, true)
+}
+
+// Difference returns a new set with the elements that are in s but not in o.
+func (s *OrderedSet[T]) Difference(o *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(o, true, false, false)
+}
+
+// SymmetricDifference returns a new set with the elements that are in either s or o but not in both.
+func (s *OrderedSet[T]) SymmetricDifference(o *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(o, true, true, false)
+}
+
+// Subset reports whether every element of s is also an element of o.
+func (s *OrderedSet[T]) Subset(o *OrderedSet[T]) bool {
+	return s.Len() <= o.Len() && s.Difference(o).Len() == 0
+}
+
+// Equal reports whether s and o contain exactly the same elements.
+func (s *OrderedSet[T]) Equal(o *OrderedSet[T]) bool {
+	return slices.Equal(s.elems, o.elems)
+}
+
+// MarshalJSON implements [json.Marshaler] for an ordered set.
+// The output is a JSON array of the elements in ascending order.
+func (s OrderedSet[T]) MarshalJSON() ([]byte, error) {
+	elems := s.elems
+	if elems == nil {
+		elems = []T{}
+	}
+
+	b, err := json.Marshal(elems)
+	if err != nil {
+		return nil, fmt.Errorf("marshal elements: %w", err)
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] for an ordered set.
+// It accepts any JSON array whose elements can be unmarshaled into T. Duplicates are discarded.
+func (s *OrderedSet[T]) UnmarshalJSON(b []byte) error {
+	var elems []T
+
+	err := json.Unmarshal(b, &elems)
+	if err != nil {
+		return fmt.Errorf("unmarshal elements: %w", err)
+	}
+
+	*s = *NewOrderedSet(elems...)
+
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler] for an ordered set.
+// The output format is the same as [Set.MarshalText] but with the elements in ascending order.
+func (s OrderedSet[T]) MarshalText() ([]byte, error) {
+	return marshalElems(slices.Values(s.elems))
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] for an ordered set.
+// It accepts any slice of bytes produced by [OrderedSet.MarshalText] or [Set.MarshalText].
+func (s *OrderedSet[T]) UnmarshalText(b []byte) error {
+	var elems []T
+
+	err := unmarshalElems(b, func(e T) { elems = append(elems, e) })
+	if err != nil {
+		return err
+	}
+
+	*s = *NewOrderedSet(elems...)
+
+	return nil
+}
+
+// merge walks both sorted sets in lockstep and keeps the elements selected by the given flags:
+// onlyS for elements only in s, onlyO for elements only in o and both for elements in s and o.
+func (s *OrderedSet[T]) merge(o *OrderedSet[T], onlyS, onlyO, both bool) *OrderedSet[T] {
+	out := make([]T, 0, max(len(s.elems), len(o.elems)))
+
+	i, j := 0, 0
+	for i < len(s.elems) && j < len(o.elems) {
+		switch c := cmp.Compare(s.elems[i], o.elems[j]); {
+		case c < 0:
+			if onlyS {
+				out = append(out, s.elems[i])
+			}
+
+			i++
+		case c > 0:
+			if onlyO {
+				out = append(out, o.elems[j])
+			}
+
+			j++
+		default:
+			if both {
+				out = append(out, s.elems[i])
+			}
+
+			i++
+			j++
+		}
+	}
+
+	if onlyS {
+		out = append(out, s.elems[i:]...)
+	}
+
+	if onlyO {
+		out = append(out, o.elems[j:]...)
+	}
+
+	return &OrderedSet[T]{elems: out}
+}
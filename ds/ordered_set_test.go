@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedSetAddRemoveContains(t *testing.T) {
+	var s ds.OrderedSet[int]
+
+	s.Add(5, 1, 3, 1)
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []int{1, 3, 5}, slices.Collect(s.All()))
+
+	s.Remove(3, 7)
+	assert.Equal(t, []int{1, 5}, slices.Collect(s.All()))
+	assert.True(t, s.Contains(5))
+	assert.False(t, s.Contains(3))
+}
+
+func TestOrderedSetOperations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a, b    *ds.OrderedSet[int]
+		wantU   []int
+		wantI   []int
+		wantD   []int
+		wantSD  []int
+		wantSub bool
+	}{
+		{
+			name:    "Empty",
+			a:       ds.NewOrderedSet[int](),
+			b:       ds.NewOrderedSet[int](),
+			wantU:   []int{},
+			wantI:   []int{},
+			wantD:   []int{},
+			wantSD:  []int{},
+			wantSub: true,
+		},
+		{
+			name:    "Overlapping",
+			a:       ds.NewOrderedSet(5, 1, 3),
+			b:       ds.NewOrderedSet(4, 3, 9),
+			wantU:   []int{1, 3, 4, 5, 9},
+			wantI:   []int{3},
+			wantD:   []int{1, 5},
+			wantSD:  []int{1, 4, 5, 9},
+			wantSub: false,
+		},
+		{
+			name:    "Subset",
+			a:       ds.NewOrderedSet(2),
+			b:       ds.NewOrderedSet(1, 2, 3),
+			wantU:   []int{1, 2, 3},
+			wantI:   []int{2},
+			wantD:   []int{},
+			wantSD:  []int{1, 3},
+			wantSub: true,
+		},
+	}
+
+	collect := func(s *ds.OrderedSet[int]) []int {
+		return append([]int{}, slices.Collect(s.All())...)
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			assert.Equal(t, tCase.wantU, collect(tCase.a.Union(tCase.b)))
+			assert.Equal(t, tCase.wantI, collect(tCase.a.Intersect(tCase.b)))
+			assert.Equal(t, tCase.wantD, collect(tCase.a.Difference(tCase.b)))
+			assert.Equal(t, tCase.wantSD, collect(tCase.a.SymmetricDifference(tCase.b)))
+			assert.Equal(t, tCase.wantSub, tCase.a.Subset(tCase.b))
+		})
+	}
+}
+
+func TestOrderedSetJSON(t *testing.T) {
+	b, err := ds.NewOrderedSet("foo", "bar").MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `["bar","foo"]`, string(b))
+
+	b, err = (&ds.OrderedSet[string]{}).MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(b))
+
+	var s ds.OrderedSet[string]
+
+	require.NoError(t, s.UnmarshalJSON([]byte(`["foo","bar","foo"]`)))
+	assert.True(t, ds.NewOrderedSet("bar", "foo").Equal(&s))
+
+	require.Error(t, s.UnmarshalJSON([]byte(`"foo"`)))
+}
+
+func TestOrderedSetText(t *testing.T) {
+	b, err := ds.NewOrderedSet(30, -1, 20).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("-1,20,30"), b)
+
+	var s ds.OrderedSet[int]
+
+	require.NoError(t, s.UnmarshalText([]byte("30,-1,20,30")))
+	assert.Equal(t, []int{-1, 20, 30}, slices.Collect(s.All()))
+
+	require.NoError(t, s.UnmarshalText([]byte("")))
+	assert.Equal(t, 0, s.Len())
+
+	require.ErrorIs(t, s.UnmarshalText([]byte("1,foo")), strconv.ErrSyntax)
+}
+
+func TestOrderedSetValueField(t *testing.T) {
+	type config struct {
+		Names ds.OrderedSet[string] `json:"names"`
+	}
+
+	var cfg config
+
+	cfg.Names.Add("b", "a")
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"names":["a","b"]}`, string(b))
+
+	var got config
+
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.True(t, cfg.Names.Equal(&got.Names))
+
+	var tm encoding.TextMarshaler = cfg.Names
+
+	b, err = tm.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a,b"), b)
+}
@@ -0,0 +1,420 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// RadixWildcard is the pattern label that matches any single label in [Radix.Match].
+const RadixWildcard = "*"
+
+// Radix is a radix tree (compressed prefix trie) that maps string keys to values of type V.
+//
+// The tree is persistent: every update copies the modified path and atomically publishes a new
+// root, so readers always observe a consistent snapshot and never block on writers. Updates are
+// serialized with a mutex. The zero value is an empty tree ready to use. A Radix must not be
+// copied after first use, use [Radix.Snapshot] instead.
+//
+// Source: https://en.wikipedia.org/wiki/Radix_tree
+type Radix[V any] struct {
+	mu   sync.Mutex
+	tree atomic.Pointer[radixTree[V]]
+}
+
+type radixTree[V any] struct {
+	root *radixNode[V]
+	size int
+}
+
+type radixNode[V any] struct {
+	prefix   string
+	leaf     bool
+	value    V
+	children []*radixNode[V] // sorted by the first byte of their prefix
+}
+
+// NewRadix creates an empty [Radix] tree.
+func NewRadix[V any]() *Radix[V] {
+	return &Radix[V]{} //nolint:exhaustruct_v5 // Zero value is ready to use.
+}
+
+// Len is the number of keys in the tree.
+func (r *Radix[V]) Len() int {
+	return r.load().size
+}
+
+// Snapshot returns an independent copy of the tree in constant time.
+// Further updates to either tree are not visible in the other.
+func (r *Radix[V]) Snapshot() *Radix[V] {
+	s := NewRadix[V]()
+	s.tree.Store(r.load())
+
+	return s
+}
+
+// Insert sets the value for key, replacing any existing value.
+// It reports whether the key was newly added to the tree.
+func (r *Radix[V]) Insert(key string, value V) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.load()
+
+	root, added := t.root.insert(key, value)
+
+	size := t.size
+	if added {
+		size++
+	}
+
+	r.tree.Store(&radixTree[V]{root: root, size: size})
+
+	return added
+}
+
+// Delete removes key from the tree. It reports whether the key was present.
+func (r *Radix[V]) Delete(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := r.load()
+
+	root, deleted := t.root.delete(key)
+	if !deleted {
+		return false
+	}
+
+	r.tree.Store(&radixTree[V]{root: root, size: t.size - 1})
+
+	return true
+}
+
+// Get returns the value for key and whether the key was found.
+func (r *Radix[V]) Get(key string) (V, bool) {
+	n := r.load().root
+
+	for {
+		if key == "" {
+			if n.leaf {
+				return n.value, true
+			}
+
+			break
+		}
+
+		c := n.child(key[0])
+		if c == nil || !strings.HasPrefix(key, c.prefix) {
+			break
+		}
+
+		key = key[len(c.prefix):]
+		n = c
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// LongestPrefix returns the longest key in the tree that is a prefix of s, its value and whether
+// such a key was found.
+func (r *Radix[V]) LongestPrefix(s string) (string, V, bool) {
+	var (
+		match string
+		value V
+		found bool
+	)
+
+	n, consumed := r.load().root, 0
+
+	for {
+		if n.leaf {
+			match, value, found = s[:consumed], n.value, true
+		}
+
+		rest := s[consumed:]
+		if rest == "" {
+			break
+		}
+
+		c := n.child(rest[0])
+		if c == nil || !strings.HasPrefix(rest, c.prefix) {
+			break
+		}
+
+		consumed += len(c.prefix)
+		n = c
+	}
+
+	return match, value, found
+}
+
+// All returns an iterator over all key/value pairs of the tree in ascending key order.
+func (r *Radix[V]) All() iter.Seq2[string, V] {
+	return r.WalkPrefix("")
+}
+
+// WalkPrefix returns an iterator over the key/value pairs whose key starts with prefix,
+// in ascending key order. The iterator operates on the snapshot taken when it was created.
+func (r *Radix[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	root := r.load().root
+
+	return func(yield func(string, V) bool) {
+		n, key := root, ""
+
+		for prefix != "" {
+			c := n.child(prefix[0])
+			if c == nil {
+				return
+			}
+
+			switch {
+			case strings.HasPrefix(c.prefix, prefix):
+				prefix = ""
+			case strings.HasPrefix(prefix, c.prefix):
+				prefix = prefix[len(c.prefix):]
+			default:
+				return
+			}
+
+			key += c.prefix
+			n = c
+		}
+
+		n.walk(key, yield)
+	}
+}
+
+// Match returns an iterator over the key/value pairs whose key matches pattern, in ascending key
+// order. Both keys and pattern are split into labels using sep. A pattern label equal to
+// [RadixWildcard] matches any single key label, all other labels must match exactly.
+// For example, with sep '.' the pattern "http.*.latency" matches "http.get.latency".
+func (r *Radix[V]) Match(pattern string, sep byte) iter.Seq2[string, V] {
+	root := r.load().root
+	m := &radixMatcher{labels: strings.Split(pattern, string(sep)), sep: sep}
+
+	return func(yield func(string, V) bool) {
+		root.match(m, radixMatchState{}, "", yield)
+	}
+}
+
+func (r *Radix[V]) load() *radixTree[V] {
+	if t := r.tree.Load(); t != nil {
+		return t
+	}
+
+	return &radixTree[V]{root: &radixNode[V]{}, size: 0} //nolint:exhaustruct_v5 // Empty root.
+}
+
+func (n *radixNode[V]) child(b byte) *radixNode[V] {
+	if i, found := n.childIndex(b); found {
+		return n.children[i]
+	}
+
+	return nil
+}
+
+func (n *radixNode[V]) childIndex(b byte) (int, bool) {
+	return slices.BinarySearchFunc(n.children, b, func(c *radixNode[V], b byte) int {
+		return int(c.prefix[0]) - int(b)
+	})
+}
+
+func (n *radixNode[V]) clone() *radixNode[V] {
+	c := *n
+	c.children = slices.Clone(n.children)
+
+	return &c
+}
+
+// insert returns a copy of n with key (relative to n) set to value and whether it was added.
+func (n *radixNode[V]) insert(key string, value V) (*radixNode[V], bool) {
+	nc := n.clone()
+
+	if key == "" {
+		added := !nc.leaf
+		nc.leaf, nc.value = true, value
+
+		return nc, added
+	}
+
+	idx, found := nc.childIndex(key[0])
+	if !found {
+		leaf := &radixNode[V]{prefix: key, leaf: true, value: value, children: nil}
+		nc.children = slices.Insert(nc.children, idx, leaf)
+
+		return nc, true
+	}
+
+	c := nc.children[idx]
+
+	l := commonPrefixLen(c.prefix, key)
+	if l == len(c.prefix) {
+		newc, added := c.insert(key[l:], value)
+		nc.children[idx] = newc
+
+		return nc, added
+	}
+
+	// Split the child edge at the common prefix.
+	tail := c.clone()
+	tail.prefix = c.prefix[l:]
+
+	var zero V
+
+	mid := &radixNode[V]{prefix: c.prefix[:l], leaf: false, value: zero, children: []*radixNode[V]{tail}}
+	if l == len(key) {
+		mid.leaf, mid.value = true, value
+	} else {
+		mid, _ = mid.insert(key[l:], value)
+	}
+
+	nc.children[idx] = mid
+
+	return nc, true
+}
+
+// delete returns a copy of n with key (relative to n) removed and whether it was present.
+// The returned node is not compacted, this is the responsibility of the parent.
+func (n *radixNode[V]) delete(key string) (*radixNode[V], bool) {
+	if key == "" {
+		if !n.leaf {
+			return n, false
+		}
+
+		var zero V
+
+		nc := n.clone()
+		nc.leaf, nc.value = false, zero
+
+		return nc, true
+	}
+
+	idx, found := n.childIndex(key[0])
+	if !found || !strings.HasPrefix(key, n.children[idx].prefix) {
+		return n, false
+	}
+
+	c := n.children[idx]
+
+	newc, deleted := c.delete(key[len(c.prefix):])
+	if !deleted {
+		return n, false
+	}
+
+	nc := n.clone()
+
+	if newc = newc.compact(); newc == nil {
+		nc.children = slices.Delete(nc.children, idx, idx+1)
+	} else {
+		nc.children[idx] = newc
+	}
+
+	return nc, true
+}
+
+// compact removes n if it holds no value and has no children, or merges it with its only child.
+// The node n must be a private copy.
+func (n *radixNode[V]) compact() *radixNode[V] {
+	if n.leaf {
+		return n
+	}
+
+	switch len(n.children) {
+	case 0:
+		return nil
+	case 1:
+		c := n.children[0].clone()
+		c.prefix = n.prefix + c.prefix
+
+		return c
+	default:
+		return n
+	}
+}
+
+func (n *radixNode[V]) walk(key string, yield func(string, V) bool) bool {
+	if n.leaf && !yield(key, n.value) {
+		return false
+	}
+
+	for _, c := range n.children {
+		if !c.walk(key+c.prefix, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (n *radixNode[V]) match(m *radixMatcher, st radixMatchState, key string, yield func(string, V) bool) bool {
+	if n.leaf && m.accepts(st) && !yield(key, n.value) {
+		return false
+	}
+
+	for _, c := range n.children {
+		cst, ok := st, true
+		for i := 0; ok && i < len(c.prefix); i++ {
+			cst, ok = m.step(cst, c.prefix[i])
+		}
+
+		if ok && !c.match(m, cst, key+c.prefix, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// radixMatcher is a deterministic automaton for label patterns used by [Radix.Match].
+type radixMatcher struct {
+	labels []string
+	sep    byte
+}
+
+// radixMatchState is the current pattern label and the offset within it.
+type radixMatchState struct {
+	label, offset int
+}
+
+func (m *radixMatcher) step(st radixMatchState, b byte) (radixMatchState, bool) {
+	lbl := m.labels[st.label]
+
+	switch {
+	case b == m.sep:
+		if (lbl != RadixWildcard && st.offset != len(lbl)) || st.label+1 == len(m.labels) {
+			return st, false
+		}
+
+		return radixMatchState{label: st.label + 1, offset: 0}, true
+	case lbl == RadixWildcard:
+		return st, true
+	case st.offset < len(lbl) && lbl[st.offset] == b:
+		return radixMatchState{label: st.label, offset: st.offset + 1}, true
+	default:
+		return st, false
+	}
+}
+
+func (m *radixMatcher) accepts(st radixMatchState) bool {
+	lbl := m.labels[st.label]
+
+	return st.label == len(m.labels)-1 && (lbl == RadixWildcard || st.offset == len(lbl))
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return n
+}
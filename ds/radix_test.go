@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func radixKeys[V any](r *ds.Radix[V]) []string {
+	var keys []string
+	for k := range r.All() {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func TestRadixInsertGet(t *testing.T) {
+	var r ds.Radix[string]
+
+	assert.True(t, r.Insert("romane", "a"))
+	assert.True(t, r.Insert("romanus", "b"))
+	assert.True(t, r.Insert("romulus", "c"))
+	assert.True(t, r.Insert("rom", "d"))
+	assert.True(t, r.Insert("", "e"))
+	assert.False(t, r.Insert("romanus", "f"))
+	assert.Equal(t, 5, r.Len())
+
+	testCases := []struct {
+		key    string
+		want   string
+		wantOk bool
+	}{
+		{key: "romane", want: "a", wantOk: true},
+		{key: "romanus", want: "f", wantOk: true},
+		{key: "romulus", want: "c", wantOk: true},
+		{key: "rom", want: "d", wantOk: true},
+		{key: "", want: "e", wantOk: true},
+		{key: "roman", want: "", wantOk: false},
+		{key: "r", want: "", wantOk: false},
+		{key: "romanes", want: "", wantOk: false},
+		{key: "x", want: "", wantOk: false},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(strconv.Quote(tCase.key), func(t *testing.T) {
+			v, ok := r.Get(tCase.key)
+			assert.Equal(t, tCase.wantOk, ok)
+			assert.Equal(t, tCase.want, v)
+		})
+	}
+}
+
+func TestRadixDelete(t *testing.T) {
+	r := ds.NewRadix[int]()
+	r.Insert("romane", 0)
+	r.Insert("romanus", 1)
+	r.Insert("romulus", 2)
+	r.Insert("rom", 3)
+
+	assert.False(t, r.Delete("roman"))
+	assert.False(t, r.Delete("x"))
+	assert.True(t, r.Delete("romanus"))
+	assert.False(t, r.Delete("romanus"))
+	assert.True(t, r.Delete("rom"))
+	assert.Equal(t, 2, r.Len())
+
+	assert.Equal(t, []string{"romane", "romulus"}, radixKeys(r))
+
+	assert.True(t, r.Delete("romane"))
+	assert.True(t, r.Delete("romulus"))
+	assert.Equal(t, 0, r.Len())
+	assert.Empty(t, radixKeys(r))
+}
+
+func TestRadixLongestPrefix(t *testing.T) {
+	keys := []string{"/", "/api", "/api/v1/users", "/static"}
+
+	r := ds.NewRadix[int]()
+	for i, k := range keys {
+		r.Insert(k, i)
+	}
+
+	testCases := []struct {
+		s         string
+		wantKey   string
+		wantValue int
+		wantOk    bool
+	}{
+		{s: "/api/v1/users/42", wantKey: "/api/v1/users", wantValue: 2, wantOk: true},
+		{s: "/api/v1/groups", wantKey: "/api", wantValue: 1, wantOk: true},
+		{s: "/api", wantKey: "/api", wantValue: 1, wantOk: true},
+		{s: "/index.html", wantKey: "/", wantValue: 0, wantOk: true},
+		{s: "index.html", wantKey: "", wantValue: 0, wantOk: false},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.s, func(t *testing.T) {
+			k, v, ok := r.LongestPrefix(tCase.s)
+			assert.Equal(t, tCase.wantOk, ok)
+			assert.Equal(t, tCase.wantKey, k)
+			assert.Equal(t, tCase.wantValue, v)
+		})
+	}
+}
+
+func TestRadixWalkPrefix(t *testing.T) {
+	keys := []string{"foo.bar", "foo.baz", "foo", "fob", "qux"}
+
+	r := ds.NewRadix[int]()
+	for i, k := range keys {
+		r.Insert(k, i)
+	}
+
+	testCases := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "", want: []string{"fob", "foo", "foo.bar", "foo.baz", "qux"}},
+		{prefix: "fo", want: []string{"fob", "foo", "foo.bar", "foo.baz"}},
+		{prefix: "foo.", want: []string{"foo.bar", "foo.baz"}},
+		{prefix: "foo.ba", want: []string{"foo.bar", "foo.baz"}},
+		{prefix: "foo.bar", want: []string{"foo.bar"}},
+		{prefix: "foo.bax", want: nil},
+		{prefix: "z", want: nil},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(strconv.Quote(tCase.prefix), func(t *testing.T) {
+			var got []string
+			for k := range r.WalkPrefix(tCase.prefix) {
+				got = append(got, k)
+			}
+
+			assert.Equal(t, tCase.want, got)
+		})
+	}
+}
+
+func TestRadixMatch(t *testing.T) {
+	keys := []string{
+		"http.get.latency",
+		"http.post.latency",
+		"http.get.count",
+		"http.latency",
+		"db.get.latency",
+	}
+
+	r := ds.NewRadix[int]()
+	for i, k := range keys {
+		r.Insert(k, i)
+	}
+
+	testCases := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: "http.*.latency", want: []string{"http.get.latency", "http.post.latency"}},
+		{pattern: "*.get.latency", want: []string{"db.get.latency", "http.get.latency"}},
+		{pattern: "http.get.*", want: []string{"http.get.count", "http.get.latency"}},
+		{pattern: "http.*", want: []string{"http.latency"}},
+		{pattern: "*.*", want: []string{"http.latency"}},
+		{pattern: "http.latency", want: []string{"http.latency"}},
+		{pattern: "http.get", want: nil},
+		{pattern: "*", want: nil},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.pattern, func(t *testing.T) {
+			var got []string
+			for k := range r.Match(tCase.pattern, '.') {
+				got = append(got, k)
+			}
+
+			assert.Equal(t, tCase.want, got)
+		})
+	}
+}
+
+func TestRadixSnapshot(t *testing.T) {
+	r := ds.NewRadix[int]()
+	r.Insert("foo", 0)
+	r.Insert("bar", 1)
+	s := r.Snapshot()
+
+	r.Insert("baz", 2)
+	r.Delete("foo")
+	s.Insert("qux", 3)
+
+	_, ok := s.Get("foo")
+	assert.True(t, ok)
+	_, ok = s.Get("baz")
+	assert.False(t, ok)
+	_, ok = r.Get("qux")
+	assert.False(t, ok)
+	assert.Equal(t, 2, r.Len())
+	assert.Equal(t, 3, s.Len())
+}
+
+func TestRadixConcurrent(t *testing.T) {
+	r := ds.NewRadix[int]()
+	ref := map[string]int{}
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for i := range 2000 {
+			k := strconv.Itoa(rand.IntN(500)) //nolint:gosec // Not security sensitive.
+			if i%3 == 0 {
+				r.Delete(k)
+				delete(ref, k)
+			} else {
+				r.Insert(k, i)
+				ref[k] = i
+			}
+		}
+	})
+
+	for range 4 {
+		wg.Go(func() {
+			for range 200 {
+				prev := ""
+				for k := range r.All() {
+					assert.Less(t, prev, k)
+					prev = k
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	require.Equal(t, len(ref), r.Len())
+	assert.Equal(t, ref, maps.Collect(r.All()))
+}
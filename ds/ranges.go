@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -61,6 +62,16 @@ func (r Ranges) Sort() {
 	sort.Sort(r)
 }
 
+// Insert inserts rng into an already sorted collection and returns the modified collection, which
+// is kept sorted as determined by the [Ranges.Less] method. It uses binary search to find the
+// insertion point, avoiding a full [Ranges.Sort] after each insertion. Like the built-in append
+// function, the result must be stored. The rng is inserted after any elements with the same Min.
+func (r Ranges) Insert(rng Range) Ranges {
+	idx := sort.Search(r.Len(), func(i int) bool { return r[i].Min > rng.Min })
+
+	return slices.Insert(r, idx, rng)
+}
+
 // Search uses binary search to find and return the first [Range] element in the collection
 // in which v is contained (min/max range values are inclusive).
 // This function uses the [sort.Search] function.
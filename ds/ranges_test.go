@@ -269,6 +269,56 @@ func TestRangesSearch(t *testing.T) {
 	}
 }
 
+func TestRangesInsert(t *testing.T) {
+	testCases := []struct {
+		name   string
+		ranges ds.Ranges
+		rng    ds.Range
+		want   ds.Ranges
+	}{
+		{
+			name:   "Empty",
+			ranges: ds.Ranges{},
+			rng:    ds.Range{Min: 1, Max: 3, Value: "foo"},
+			want: ds.Ranges{
+				{Min: 1, Max: 3, Value: "foo"},
+			},
+		},
+		{
+			name: "Middle",
+			ranges: ds.Ranges{
+				{Min: 1, Max: 3, Value: "foo"},
+				{Min: 5, Max: 7, Value: "bar"},
+			},
+			rng: ds.Range{Min: 2, Max: 5, Value: "baz"},
+			want: ds.Ranges{
+				{Min: 1, Max: 3, Value: "foo"},
+				{Min: 2, Max: 5, Value: "baz"},
+				{Min: 5, Max: 7, Value: "bar"},
+			},
+		},
+		{
+			name: "SameMin",
+			ranges: ds.Ranges{
+				{Min: 1, Max: 3, Value: "foo"},
+				{Min: 5, Max: 7, Value: "bar"},
+			},
+			rng: ds.Range{Min: 5, Max: 6, Value: "baz"},
+			want: ds.Ranges{
+				{Min: 1, Max: 3, Value: "foo"},
+				{Min: 5, Max: 7, Value: "bar"},
+				{Min: 5, Max: 6, Value: "baz"},
+			},
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			assert.Equal(t, tCase.want, tCase.ranges.Insert(tCase.rng))
+		})
+	}
+}
+
 func TestBareRangeMarshalText(t *testing.T) {
 	testCases := []struct {
 		name    string
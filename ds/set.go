@@ -0,0 +1,314 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"strconv"
+)
+
+// Set is an unordered collection of unique comparable elements backed by a map.
+// The zero value is an empty set that can be read but not written to, use [NewSet] instead.
+type Set[T comparable] map[T]struct{} //nolint:recvcheck // Unmarshalers need pointer receivers.
+
+// NewSet creates a [Set] containing the given elements.
+func NewSet[T comparable](elems ...T) Set[T] {
+	s := make(Set[T], len(elems))
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+
+	return s
+}
+
+// Add adds the given elements to the set.
+func (s Set[T]) Add(elems ...T) {
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+}
+
+// Remove removes the given elements from the set.
+func (s Set[T]) Remove(elems ...T) {
+	for _, e := range elems {
+		delete(s, e)
+	}
+}
+
+// Contains reports whether e is an element of the set.
+func (s Set[T]) Contains(e T) bool {
+	_, ok := s[e]
+
+	return ok
+}
+
+// Len is the number of elements in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// All returns an iterator over the elements of the set in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Clone returns a shallow copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	out := make(Set[T], len(s))
+	maps.Copy(out, s)
+
+	return out
+}
+
+// Union returns a new set with the elements that are in s or in o.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	out := s.Clone()
+	maps.Copy(out, o)
+
+	return out
+}
+
+// Intersect returns a new set with the elements that are in both s and o.
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	small, large := s, o
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	out := make(Set[T])
+
+	for e := range small {
+		if large.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+
+	return out
+}
+
+// Difference returns a new set with the elements that are in s but not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	out := make(Set[T])
+
+	for e := range s {
+		if !o.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+
+	return out
+}
+
+// SymmetricDifference returns a new set with the elements that are in either s or o but not in both.
+func (s Set[T]) SymmetricDifference(o Set[T]) Set[T] {
+	out := s.Difference(o)
+
+	for e := range o {
+		if !s.Contains(e) {
+			out[e] = struct{}{}
+		}
+	}
+
+	return out
+}
+
+// Subset reports whether every element of s is also an element of o.
+func (s Set[T]) Subset(o Set[T]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+
+	for e := range s {
+		if !o.Contains(e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether s and o contain exactly the same elements.
+func (s Set[T]) Equal(o Set[T]) bool {
+	return len(s) == len(o) && s.Subset(o)
+}
+
+// MarshalJSON implements [json.Marshaler] for a set.
+// The output is a JSON array of the elements in no particular order.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	elems := make([]T, 0, len(s))
+	for e := range s {
+		elems = append(elems, e)
+	}
+
+	b, err := json.Marshal(elems)
+	if err != nil {
+		return nil, fmt.Errorf("marshal elements: %w", err)
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON implements [json.Unmarshaler] for a set.
+// It accepts any JSON array whose elements can be unmarshaled into T. Duplicates are discarded.
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var elems []T
+
+	err := json.Unmarshal(b, &elems)
+	if err != nil {
+		return fmt.Errorf("unmarshal elements: %w", err)
+	}
+
+	*s = NewSet(elems...)
+
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler] for a set.
+// The output format is "elem,elem,..." in no particular order, where each element is formatted
+// using its own [encoding.TextMarshaler] implementation if available, or its string, integer,
+// floating-point or boolean representation otherwise. Elements formatted as empty text or
+// containing commas cannot be parsed back and return [ErrUnknownFormat].
+func (s Set[T]) MarshalText() ([]byte, error) {
+	return marshalElems(s.All())
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] for a set.
+// It accepts any slice of bytes produced by [Set.MarshalText].
+func (s *Set[T]) UnmarshalText(b []byte) error {
+	*s = Set[T]{}
+
+	return unmarshalElems(b, func(e T) { (*s)[e] = struct{}{} })
+}
+
+// marshalElems formats a sequence of elements as comma-separated text.
+// Elements formatted as empty text or containing the separator are not supported.
+func marshalElems[T any](seq iter.Seq[T]) ([]byte, error) {
+	out := []byte{}
+
+	const sep = byte(',')
+
+	for e := range seq {
+		b, err := marshalElem(e)
+		if err != nil {
+			return nil, fmt.Errorf("%v: marshal text: %w", e, err)
+		}
+
+		if len(b) == 0 || bytes.IndexByte(b, sep) >= 0 {
+			return nil, fmt.Errorf("%q: marshal text: %w", string(b), ErrUnknownFormat)
+		}
+
+		if len(out) > 0 {
+			out = append(out, sep)
+		}
+
+		out = append(out, b...)
+	}
+
+	return out, nil
+}
+
+// unmarshalElems parses comma-separated text and calls yield for each parsed element.
+func unmarshalElems[T any](b []byte, yield func(T)) error {
+	if len(b) == 0 {
+		return nil
+	}
+
+	for p := range bytes.SplitSeq(b, []byte{','}) {
+		var e T
+
+		err := unmarshalElem(p, &e)
+		if err != nil {
+			return fmt.Errorf("%q: unmarshal text: %w", string(p), err)
+		}
+
+		yield(e)
+	}
+
+	return nil
+}
+
+func marshalElem(v any) ([]byte, error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("marshal text: %w", err)
+		}
+
+		return b, nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { //nolint:exhaustive // Only basic kinds are supported.
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	default:
+		return nil, fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+}
+
+func unmarshalElem(b []byte, dst any) error {
+	if u, ok := dst.(encoding.TextUnmarshaler); ok {
+		err := u.UnmarshalText(b)
+		if err != nil {
+			return fmt.Errorf("unmarshal text: %w", err)
+		}
+
+		return nil
+	}
+
+	rv := reflect.ValueOf(dst).Elem()
+	str := string(b)
+
+	switch rv.Kind() { //nolint:exhaustive // Only basic kinds are supported.
+	case reflect.String:
+		rv.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(str, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("parse int: %w", err)
+		}
+
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(str, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("parse uint: %w", err)
+		}
+
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(str, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("parse float: %w", err)
+		}
+
+		rv.SetFloat(n)
+	case reflect.Bool:
+		n, err := strconv.ParseBool(str)
+		if err != nil {
+			return fmt.Errorf("parse bool: %w", err)
+		}
+
+		rv.SetBool(n)
+	default:
+		return fmt.Errorf("%s: %w", rv.Type(), ErrUnsupportedType)
+	}
+
+	return nil
+}
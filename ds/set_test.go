@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAddRemoveContains(t *testing.T) {
+	s := ds.NewSet(1, 2, 3)
+	assert.Equal(t, 3, s.Len())
+
+	s.Add(3, 4)
+	assert.Equal(t, 4, s.Len())
+	assert.True(t, s.Contains(4))
+
+	s.Remove(1, 5)
+	assert.Equal(t, 3, s.Len())
+	assert.False(t, s.Contains(1))
+
+	assert.ElementsMatch(t, []int{2, 3, 4}, slices.Collect(s.All()))
+}
+
+func TestSetOperations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a, b    ds.Set[string]
+		wantU   ds.Set[string]
+		wantI   ds.Set[string]
+		wantD   ds.Set[string]
+		wantSD  ds.Set[string]
+		wantSub bool
+	}{
+		{
+			name:    "Empty",
+			a:       ds.NewSet[string](),
+			b:       ds.NewSet[string](),
+			wantU:   ds.NewSet[string](),
+			wantI:   ds.NewSet[string](),
+			wantD:   ds.NewSet[string](),
+			wantSD:  ds.NewSet[string](),
+			wantSub: true,
+		},
+		{
+			name:    "Overlapping",
+			a:       ds.NewSet("foo", "bar", "baz"),
+			b:       ds.NewSet("baz", "qux"),
+			wantU:   ds.NewSet("foo", "bar", "baz", "qux"),
+			wantI:   ds.NewSet("baz"),
+			wantD:   ds.NewSet("foo", "bar"),
+			wantSD:  ds.NewSet("foo", "bar", "qux"),
+			wantSub: false,
+		},
+		{
+			name:    "Subset",
+			a:       ds.NewSet("foo"),
+			b:       ds.NewSet("foo", "bar"),
+			wantU:   ds.NewSet("foo", "bar"),
+			wantI:   ds.NewSet("foo"),
+			wantD:   ds.NewSet[string](),
+			wantSD:  ds.NewSet("bar"),
+			wantSub: true,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			assert.Equal(t, tCase.wantU, tCase.a.Union(tCase.b))
+			assert.Equal(t, tCase.wantI, tCase.a.Intersect(tCase.b))
+			assert.Equal(t, tCase.wantD, tCase.a.Difference(tCase.b))
+			assert.Equal(t, tCase.wantSD, tCase.a.SymmetricDifference(tCase.b))
+			assert.Equal(t, tCase.wantSub, tCase.a.Subset(tCase.b))
+		})
+	}
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	b, err := ds.NewSet(5).MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[5]`, string(b))
+
+	b, err = ds.NewSet[int]().MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(b))
+}
+
+func TestSetUnmarshalJSON(t *testing.T) {
+	var s ds.Set[int]
+
+	err := s.UnmarshalJSON([]byte(`[3,1,3,2]`))
+	require.NoError(t, err)
+	assert.Equal(t, ds.NewSet(1, 2, 3), s)
+
+	err = s.UnmarshalJSON([]byte(`{"foo":1}`))
+	require.Error(t, err)
+}
+
+func TestSetMarshalText(t *testing.T) {
+	testCases := []struct {
+		name    string
+		set     ds.Set[any]
+		want    []byte
+		wantErr error
+	}{
+		{
+			name:    "Empty",
+			set:     ds.NewSet[any](),
+			want:    []byte(""),
+			wantErr: nil,
+		},
+		{
+			name:    "String",
+			set:     ds.NewSet[any]("foo"),
+			want:    []byte("foo"),
+			wantErr: nil,
+		},
+		{
+			name:    "TextMarshaler",
+			set:     ds.NewSet[any](ds.BareRange{Min: 1, Max: 5, Value: nil}),
+			want:    []byte("1:5"),
+			wantErr: nil,
+		},
+		{
+			name:    "UnsupportedType",
+			set:     ds.NewSet[any](struct{}{}),
+			want:    nil,
+			wantErr: ds.ErrUnsupportedType,
+		},
+		{
+			name:    "Comma",
+			set:     ds.NewSet[any]("a,b"),
+			want:    nil,
+			wantErr: ds.ErrUnknownFormat,
+		},
+		{
+			name:    "EmptyString",
+			set:     ds.NewSet[any]("foo", ""),
+			want:    nil,
+			wantErr: ds.ErrUnknownFormat,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			b, err := tCase.set.MarshalText()
+			require.ErrorIs(t, err, tCase.wantErr)
+
+			if tCase.wantErr == nil {
+				assert.Equal(t, tCase.want, b)
+			}
+		})
+	}
+}
+
+func TestSetTextRoundTrip(t *testing.T) {
+	for _, set := range []ds.Set[string]{
+		ds.NewSet[string](),
+		ds.NewSet("foo"),
+		ds.NewSet("foo", "bar baz", "a;b"),
+	} {
+		b, err := set.MarshalText()
+		require.NoError(t, err)
+
+		var got ds.Set[string]
+
+		require.NoError(t, got.UnmarshalText(b))
+		assert.Equal(t, set, got)
+	}
+}
+
+func TestSetUnmarshalText(t *testing.T) {
+	t.Run("Int", func(t *testing.T) {
+		var s ds.Set[int8]
+
+		require.NoError(t, s.UnmarshalText([]byte("1,-2,1")))
+		assert.Equal(t, ds.NewSet[int8](1, -2), s)
+
+		require.ErrorIs(t, s.UnmarshalText([]byte("1,foo")), strconv.ErrSyntax)
+		require.ErrorIs(t, s.UnmarshalText([]byte("1,300")), strconv.ErrRange)
+	})
+
+	t.Run("Uint", func(t *testing.T) {
+		var s ds.Set[uint]
+
+		require.NoError(t, s.UnmarshalText([]byte("1,2")))
+		assert.Equal(t, ds.NewSet[uint](1, 2), s)
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		var s ds.Set[float64]
+
+		require.NoError(t, s.UnmarshalText([]byte("1.5,2")))
+		assert.Equal(t, ds.NewSet(1.5, 2.0), s)
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		var s ds.Set[bool]
+
+		require.NoError(t, s.UnmarshalText([]byte("true,false,true")))
+		assert.Equal(t, ds.NewSet(true, false), s)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		var s ds.Set[string]
+
+		require.NoError(t, s.UnmarshalText([]byte("")))
+		assert.Equal(t, ds.Set[string]{}, s)
+
+		require.NoError(t, s.UnmarshalText([]byte("foo,bar")))
+		assert.Equal(t, ds.NewSet("foo", "bar"), s)
+	})
+
+	t.Run("TextUnmarshaler", func(t *testing.T) {
+		var s ds.Set[ds.BareRange]
+
+		require.NoError(t, s.UnmarshalText([]byte("1:5,7")))
+		assert.Equal(t, ds.NewSet(
+			ds.BareRange{Min: 1, Max: 5, Value: struct{}{}},
+			ds.BareRange{Min: 7, Max: 7, Value: struct{}{}},
+		), s)
+
+		require.ErrorIs(t, s.UnmarshalText([]byte("1::5")), ds.ErrUnknownFormat)
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		var s ds.Set[[2]int]
+
+		require.ErrorIs(t, s.UnmarshalText([]byte("foo")), ds.ErrUnsupportedType)
+	})
+}
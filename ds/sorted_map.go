@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// sortedMapMaxLevel is the maximum number of levels of a [SortedMap] skip list,
+// which is enough for 2^32 elements with a promotion probability of 1/2.
+const sortedMapMaxLevel = 32
+
+// SortedMap is an ordered map backed by a skip list. Insertions, deletions and lookups take
+// O(log n) expected time, and iteration is in ascending key order.
+// The zero value is an empty map ready to use. A SortedMap is not safe for concurrent use.
+//
+// Source: https://en.wikipedia.org/wiki/Skip_list
+type SortedMap[K cmp.Ordered, V any] struct {
+	head  *sortedMapNode[K, V]
+	level int
+	size  int
+}
+
+type sortedMapNode[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	next  []*sortedMapNode[K, V]
+}
+
+// NewSortedMap creates an empty [SortedMap].
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{head: nil, level: 0, size: 0}
+}
+
+// Len is the number of keys in the map.
+func (m *SortedMap[K, V]) Len() int {
+	return m.size
+}
+
+// Set sets the value for key, replacing any existing value.
+// It reports whether the key was newly added to the map.
+func (m *SortedMap[K, V]) Set(key K, value V) bool {
+	var update [sortedMapMaxLevel]*sortedMapNode[K, V]
+
+	if n := m.search(key, &update); n != nil && n.key == key {
+		n.value = value
+
+		return false
+	}
+
+	lvl := 1 + bits.TrailingZeros64(rand.Uint64()|1<<(sortedMapMaxLevel-1)) //nolint:gosec // Not security sensitive.
+	for i := m.level; i < lvl; i++ {
+		update[i] = m.head
+	}
+
+	m.level = max(m.level, lvl)
+
+	n := &sortedMapNode[K, V]{key: key, value: value, next: make([]*sortedMapNode[K, V], lvl)}
+	for i := range lvl {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+
+	m.size++
+
+	return true
+}
+
+// Get returns the value for key and whether the key was found.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	if n := m.search(key, nil); n != nil && n.key == key {
+		return n.value, true
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// Delete removes key from the map. It reports whether the key was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	var update [sortedMapMaxLevel]*sortedMapNode[K, V]
+
+	n := m.search(key, &update)
+	if n == nil || n.key != key {
+		return false
+	}
+
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+
+	for m.level > 0 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+
+	m.size--
+
+	return true
+}
+
+// Min returns the smallest key of the map, its value and whether the map is not empty.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	return m.result(m.first())
+}
+
+// Max returns the largest key of the map, its value and whether the map is not empty.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	if m.head == nil {
+		return m.result(nil)
+	}
+
+	n := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for n.next[i] != nil {
+			n = n.next[i]
+		}
+	}
+
+	if n == m.head {
+		n = nil
+	}
+
+	return m.result(n)
+}
+
+// Floor returns the largest key less than or equal to key, its value and whether it was found.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	var update [sortedMapMaxLevel]*sortedMapNode[K, V]
+
+	n := m.search(key, &update)
+	if n != nil && n.key == key {
+		return m.result(n)
+	}
+
+	if pred := update[0]; pred != nil && pred != m.head {
+		return m.result(pred)
+	}
+
+	return m.result(nil)
+}
+
+// Ceiling returns the smallest key greater than or equal to key, its value and whether it was found.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return m.result(m.search(key, nil))
+}
+
+// All returns an iterator over all key/value pairs of the map in ascending key order.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.first(); n != nil; n = n.next[0] {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Scan returns an iterator over the key/value pairs with keys in the [lo, hi] range (inclusive)
+// in ascending key order.
+func (m *SortedMap[K, V]) Scan(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := m.search(lo, nil); n != nil && n.key <= hi; n = n.next[0] {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
+}
+
+// search returns the first node with a key greater than or equal to key (or nil).
+// If update is not nil, it is filled with the rightmost node before key at each level.
+func (m *SortedMap[K, V]) search(
+	key K,
+	update *[sortedMapMaxLevel]*sortedMapNode[K, V],
+) *sortedMapNode[K, V] {
+	if m.head == nil {
+		m.head = new(sortedMapNode[K, V])
+		m.head.next = make([]*sortedMapNode[K, V], sortedMapMaxLevel)
+	}
+
+	n := m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && n.next[i].key < key {
+			n = n.next[i]
+		}
+
+		if update != nil {
+			update[i] = n
+		}
+	}
+
+	if update != nil && m.level == 0 {
+		update[0] = m.head
+	}
+
+	return n.next[0]
+}
+
+func (m *SortedMap[K, V]) first() *sortedMapNode[K, V] {
+	if m.head == nil {
+		return nil
+	}
+
+	return m.head.next[0]
+}
+
+func (m *SortedMap[K, V]) result(n *sortedMapNode[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			zeroK K
+			zeroV V
+		)
+
+		return zeroK, zeroV, false
+	}
+
+	return n.key, n.value, true
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package ds_test
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/ds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedMapSetGetDelete(t *testing.T) {
+	var m ds.SortedMap[string, int]
+
+	_, ok := m.Get("foo")
+	assert.False(t, ok)
+	assert.False(t, m.Delete("foo"))
+
+	assert.True(t, m.Set("foo", 1))
+	assert.True(t, m.Set("bar", 2))
+	assert.False(t, m.Set("foo", 3))
+	assert.Equal(t, 2, m.Len())
+
+	v, ok := m.Get("foo")
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	assert.True(t, m.Delete("foo"))
+	assert.False(t, m.Delete("foo"))
+	assert.Equal(t, 1, m.Len())
+
+	_, ok = m.Get("foo")
+	assert.False(t, ok)
+}
+
+func TestSortedMapRandomized(t *testing.T) {
+	m := ds.NewSortedMap[int, int]()
+	ref := map[int]int{}
+	rnd := rand.New(rand.NewPCG(1, 2)) //nolint:gosec // Not security sensitive.
+
+	for i := range 20000 {
+		k := rnd.IntN(2000)
+		if rnd.IntN(3) == 0 {
+			_, want := ref[k]
+			assert.Equal(t, want, m.Delete(k))
+			delete(ref, k)
+		} else {
+			_, exists := ref[k]
+			assert.Equal(t, !exists, m.Set(k, i))
+			ref[k] = i
+		}
+	}
+
+	require.Equal(t, len(ref), m.Len())
+
+	var keys []int
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		assert.Equal(t, ref[k], v)
+	}
+
+	assert.Equal(t, slices.Sorted(maps.Keys(ref)), keys)
+}
+
+func TestSortedMapMinMax(t *testing.T) {
+	var m ds.SortedMap[int, string]
+
+	_, _, ok := m.Min()
+	assert.False(t, ok)
+	_, _, ok = m.Max()
+	assert.False(t, ok)
+
+	m.Set(5, "5")
+	m.Set(1, "1")
+	m.Set(9, "9")
+
+	k, _, ok := m.Min()
+	assert.True(t, ok)
+	assert.Equal(t, 1, k)
+
+	k, _, ok = m.Max()
+	assert.True(t, ok)
+	assert.Equal(t, 9, k)
+}
+
+func TestSortedMapFloorCeiling(t *testing.T) {
+	m := ds.NewSortedMap[int, string]()
+	for _, k := range []int{10, 20, 30} {
+		m.Set(k, strconv.Itoa(k))
+	}
+
+	testCases := []struct {
+		name        string
+		key         int
+		wantFloor   int
+		wantFloorOk bool
+		wantCeil    int
+		wantCeilOk  bool
+	}{
+		{name: "BelowMin", key: 5, wantFloor: 0, wantFloorOk: false, wantCeil: 10, wantCeilOk: true},
+		{name: "Exact", key: 20, wantFloor: 20, wantFloorOk: true, wantCeil: 20, wantCeilOk: true},
+		{name: "Between", key: 25, wantFloor: 20, wantFloorOk: true, wantCeil: 30, wantCeilOk: true},
+		{name: "AboveMax", key: 35, wantFloor: 30, wantFloorOk: true, wantCeil: 0, wantCeilOk: false},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			k, _, ok := m.Floor(tCase.key)
+			assert.Equal(t, tCase.wantFloorOk, ok)
+			assert.Equal(t, tCase.wantFloor, k)
+
+			k, _, ok = m.Ceiling(tCase.key)
+			assert.Equal(t, tCase.wantCeilOk, ok)
+			assert.Equal(t, tCase.wantCeil, k)
+		})
+	}
+}
+
+func TestSortedMapScan(t *testing.T) {
+	m := ds.NewSortedMap[int, string]()
+	for _, k := range []int{1, 3, 5, 7, 9} {
+		m.Set(k, strconv.Itoa(k))
+	}
+
+	testCases := []struct {
+		name   string
+		lo, hi int
+		want   []int
+	}{
+		{name: "All", lo: 0, hi: 10, want: []int{1, 3, 5, 7, 9}},
+		{name: "Inclusive", lo: 3, hi: 7, want: []int{3, 5, 7}},
+		{name: "Exclusive", lo: 2, hi: 6, want: []int{3, 5}},
+		{name: "Empty", lo: 10, hi: 20, want: nil},
+		{name: "Reversed", lo: 7, hi: 3, want: nil},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var got []int
+			for k := range m.Scan(tCase.lo, tCase.hi) {
+				got = append(got, k)
+			}
+
+			assert.Equal(t, tCase.want, got)
+		})
+	}
+
+	var got []int
+	for k := range m.Scan(0, 10) {
+		got = append(got, k)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{1, 3}, got)
+}
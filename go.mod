@@ -3,15 +3,16 @@ module github.com/hhromic/go-toolkit
 go 1.25.0
 
 require (
+	github.com/go-logr/logr v1.4.3
 	github.com/lmittmann/tint v1.2.0
 	github.com/mattn/go-colorable v0.1.15
 	github.com/mattn/go-isatty v0.0.24
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/sys v0.47.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/sys v0.47.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
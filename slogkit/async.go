@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultAsyncQueueSize is the queue size used by [NewAsyncHandler] if none is configured.
+const DefaultAsyncQueueSize = 1024
+
+// OverflowPolicy is the behaviour of an [AsyncHandler] when its queue is full.
+type OverflowPolicy int
+
+// Supported overflow policies.
+const (
+	// OverflowBlock blocks the caller until there is space in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the record being logged.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest record in the queue to make space.
+	OverflowDropOldest
+	// OverflowDropBelowLevel drops the record being logged if its level is below the configured
+	// drop level, otherwise it blocks the caller like OverflowBlock.
+	OverflowDropBelowLevel
+)
+
+// String returns a name for the overflow policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropBelowLevel:
+		return "drop-below-level"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", p)
+	}
+}
+
+// AsyncConfig configures an [AsyncHandler].
+type AsyncConfig struct {
+	// QueueSize is the maximum number of queued records, [DefaultAsyncQueueSize] if 0.
+	QueueSize int
+	// Overflow is the policy applied when the queue is full.
+	Overflow OverflowPolicy
+	// DropLevel is the level below which records are dropped with [OverflowDropBelowLevel].
+	DropLevel slog.Level
+	// SummaryInterval is the period for emitting a summary record about dropped records,
+	// 0 disables periodic summaries. A final summary is always emitted on close.
+	SummaryInterval time.Duration
+}
+
+// AsyncHandler is an slog Handler that passes records to another handler from a background
+// goroutine through a bounded queue, so that logging does not block on slow outputs.
+// Handlers derived with [AsyncHandler.WithAttrs] and [AsyncHandler.WithGroup] share the queue.
+// Errors returned by the wrapped handler are discarded.
+type AsyncHandler struct {
+	q       *asyncQueue
+	handler slog.Handler
+}
+
+// asyncEntry is a queued record together with the handler that must handle it.
+type asyncEntry struct {
+	ctx     context.Context //nolint:containedctx // Context of the original call.
+	handler slog.Handler
+	record  slog.Record
+}
+
+// flushWaiter is a pending flush waiting for the handled counter to reach target.
+type flushWaiter struct {
+	target uint64
+	done   chan struct{}
+}
+
+// asyncQueue is the state shared by an AsyncHandler and all its derived handlers.
+type asyncQueue struct {
+	next slog.Handler
+	cfg  AsyncConfig
+
+	mu      sync.RWMutex // write-locked to close entries
+	closed  bool
+	entries chan asyncEntry
+	done    chan struct{}
+
+	enqueued atomic.Uint64 // accepted or dropped records
+	handled  atomic.Uint64 // handled or dropped records
+	dropped  atomic.Uint64
+	pending  atomic.Uint64 // dropped since the last summary
+
+	waitersMu sync.Mutex
+	waiters   []flushWaiter
+}
+
+// NewAsyncHandler creates an [AsyncHandler] that passes records to next using cfg and starts its
+// background goroutine. The handler must be closed with [AsyncHandler.Close] after use.
+func NewAsyncHandler(next slog.Handler, cfg AsyncConfig) *AsyncHandler {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultAsyncQueueSize
+	}
+
+	q := &asyncQueue{ //nolint:exhaustruct_v5 // Zero values are valid.
+		next:    next,
+		cfg:     cfg,
+		entries: make(chan asyncEntry, cfg.QueueSize),
+		done:    make(chan struct{}),
+	}
+
+	go q.run()
+
+	return &AsyncHandler{q: q, handler: next}
+}
+
+// Dropped returns the total number of records dropped because the queue was full.
+func (h *AsyncHandler) Dropped() uint64 {
+	return h.q.dropped.Load()
+}
+
+// Enabled implements [slog.Handler] by calling the wrapped handler.
+func (h *AsyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements [slog.Handler] by queueing a copy of r according to the overflow policy.
+// It returns [ErrHandlerClosed] if the handler is closed.
+func (h *AsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.q.mu.RLock()
+	defer h.q.mu.RUnlock()
+
+	if h.q.closed {
+		return ErrHandlerClosed
+	}
+
+	h.q.enqueued.Add(1)
+	h.q.enqueue(asyncEntry{ctx: context.WithoutCancel(ctx), handler: h.handler, record: r.Clone()})
+
+	return nil
+}
+
+// WithAttrs implements [slog.Handler]. The returned handler shares the queue of h.
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &AsyncHandler{q: h.q, handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements [slog.Handler]. The returned handler shares the queue of h.
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &AsyncHandler{q: h.q, handler: h.handler.WithGroup(name)}
+}
+
+// Flush waits until all the records queued before the call have been handled or until ctx is
+// done, in which case it returns the context error.
+func (h *AsyncHandler) Flush(ctx context.Context) error {
+	w := flushWaiter{target: h.q.enqueued.Load(), done: make(chan struct{})}
+
+	h.q.waitersMu.Lock()
+	h.q.waiters = append(h.q.waiters, w)
+	h.q.waitersMu.Unlock()
+	h.q.notifyWaiters()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-h.q.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("flush: %w", ctx.Err())
+	}
+}
+
+// Close stops accepting records and waits until all queued records have been handled or until
+// ctx is done, in which case it returns the context error and the remaining records are handled
+// in the background. Closing any derived handler closes the shared queue.
+func (h *AsyncHandler) Close(ctx context.Context) error {
+	h.q.mu.Lock()
+	if !h.q.closed {
+		h.q.closed = true
+		close(h.q.entries)
+	}
+	h.q.mu.Unlock()
+
+	select {
+	case <-h.q.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("close: %w", ctx.Err())
+	}
+}
+
+// enqueue adds e to the queue applying the overflow policy, must be called with the read lock.
+func (q *asyncQueue) enqueue(e asyncEntry) {
+	select {
+	case q.entries <- e:
+		return
+	default:
+	}
+
+	switch q.cfg.Overflow {
+	case OverflowDropNewest:
+		q.drop()
+
+		return
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.entries <- e:
+				return
+			default:
+			}
+
+			select {
+			case <-q.entries:
+				q.drop()
+			default:
+			}
+		}
+	case OverflowDropBelowLevel:
+		if e.record.Level < q.cfg.DropLevel {
+			q.drop()
+
+			return
+		}
+	case OverflowBlock:
+	}
+
+	q.entries <- e
+}
+
+// drop accounts for a dropped record.
+func (q *asyncQueue) drop() {
+	q.dropped.Add(1)
+	q.pending.Add(1)
+	q.handled.Add(1)
+	q.notifyWaiters()
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	var tick <-chan time.Time
+
+	if q.cfg.SummaryInterval > 0 {
+		ticker := time.NewTicker(q.cfg.SummaryInterval)
+		defer ticker.Stop()
+
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case e, ok := <-q.entries:
+			if !ok {
+				q.summary()
+
+				return
+			}
+
+			_ = e.handler.Handle(e.ctx, e.record)
+
+			q.handled.Add(1)
+			q.notifyWaiters()
+		case <-tick:
+			q.summary()
+		}
+	}
+}
+
+// summary emits a warning record with the number of records dropped since the last summary.
+func (q *asyncQueue) summary() {
+	n := q.pending.Swap(0)
+	if n == 0 || !q.next.Enabled(context.Background(), slog.LevelWarn) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "log records dropped", 0)
+	r.AddAttrs(slog.Uint64("dropped", n), slog.Uint64("total_dropped", q.dropped.Load()))
+
+	_ = q.next.Handle(context.Background(), r)
+}
+
+// notifyWaiters releases the flush waiters whose target has been reached.
+func (q *asyncQueue) notifyWaiters() {
+	q.waitersMu.Lock()
+	defer q.waitersMu.Unlock()
+
+	if len(q.waiters) == 0 {
+		return
+	}
+
+	handled := q.handled.Load()
+	q.waiters = slices.DeleteFunc(q.waiters, func(w flushWaiter) bool {
+		if handled >= w.target {
+			close(w.done)
+
+			return true
+		}
+
+		return false
+	})
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gateWriter is a writer that blocks until its gate is opened.
+type gateWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	once    sync.Once
+	entered chan struct{} // closed on the first write
+	gate    chan struct{}
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{entered: make(chan struct{}), gate: make(chan struct{})} //nolint:exhaustruct_v5
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.entered) })
+	<-w.gate
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.Write(p)
+}
+
+func (w *gateWriter) Messages() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var msgs []string
+
+	for l := range strings.Lines(w.buf.String()) {
+		_, msg, _ := strings.Cut(l, "msg=")
+		msgs = append(msgs, strings.TrimSpace(msg))
+	}
+
+	return msgs
+}
+
+// fillAsync logs a blocked first record and then n records that fill the queue.
+func fillAsync(t *testing.T, logger *slog.Logger, w *gateWriter, n int) {
+	t.Helper()
+
+	logger.Info("m0")
+	<-w.entered // The background goroutine is blocked writing the first record.
+
+	for i := 1; i <= n; i++ {
+		logger.Info("m" + string(rune('0'+i)))
+	}
+}
+
+func TestAsyncHandlerOverflow(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  slogkit.OverflowPolicy
+		want    []string
+		dropped uint64
+	}{
+		{
+			name:    "DropNewest",
+			policy:  slogkit.OverflowDropNewest,
+			want:    []string{"m0", "m1", "m2"},
+			dropped: 2,
+		},
+		{
+			name:    "DropOldest",
+			policy:  slogkit.OverflowDropOldest,
+			want:    []string{"m0", "m3", "m4"},
+			dropped: 2,
+		},
+		{
+			name:    "DropBelowLevel",
+			policy:  slogkit.OverflowDropBelowLevel,
+			want:    []string{"m0", "m1", "m2"},
+			dropped: 2,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			w := newGateWriter()
+			h := slogkit.NewAsyncHandler(slog.NewTextHandler(w, nil), slogkit.AsyncConfig{
+				QueueSize:       2,
+				Overflow:        tCase.policy,
+				DropLevel:       slog.LevelWarn,
+				SummaryInterval: 0,
+			})
+			logger := slog.New(h)
+
+			fillAsync(t, logger, w, 4)
+			assert.Equal(t, tCase.dropped, h.Dropped())
+
+			close(w.gate)
+			require.NoError(t, h.Close(t.Context()))
+			assert.Equal(t, append(tCase.want, `"log records dropped" dropped=2 total_dropped=2`), w.Messages())
+		})
+	}
+}
+
+func TestAsyncHandlerBlock(t *testing.T) {
+	w := newGateWriter()
+	h := slogkit.NewAsyncHandler(slog.NewTextHandler(w, nil), slogkit.AsyncConfig{
+		QueueSize:       1,
+		Overflow:        slogkit.OverflowDropBelowLevel,
+		DropLevel:       slog.LevelWarn,
+		SummaryInterval: 0,
+	})
+	logger := slog.New(h)
+
+	fillAsync(t, logger, w, 1)
+
+	logged := make(chan struct{})
+
+	go func() {
+		defer close(logged)
+
+		logger.Warn("blocked")
+	}()
+
+	select {
+	case <-logged:
+		assert.Fail(t, "warning record should block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(w.gate)
+	<-logged
+
+	require.NoError(t, h.Flush(t.Context()))
+	assert.Equal(t, []string{"m0", "m1", "blocked"}, w.Messages())
+	assert.Zero(t, h.Dropped())
+
+	require.NoError(t, h.Close(t.Context()))
+	require.ErrorIs(t, h.Handle(t.Context(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)),
+		slogkit.ErrHandlerClosed)
+}
+
+func TestAsyncHandlerSummary(t *testing.T) {
+	w := newGateWriter()
+	h := slogkit.NewAsyncHandler(slog.NewTextHandler(w, nil), slogkit.AsyncConfig{
+		QueueSize:       1,
+		Overflow:        slogkit.OverflowDropNewest,
+		DropLevel:       0,
+		SummaryInterval: time.Millisecond,
+	})
+	logger := slog.New(h)
+
+	fillAsync(t, logger, w, 3)
+	close(w.gate)
+
+	assert.Eventually(t, func() bool {
+		return len(w.Messages()) == 3
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, w.Messages(), `"log records dropped" dropped=2 total_dropped=2`)
+
+	require.NoError(t, h.Close(t.Context()))
+	assert.Len(t, w.Messages(), 3)
+}
+
+func TestAsyncHandlerAttrs(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := slogkit.NewAsyncHandler(slog.NewTextHandler(&buf, nil), slogkit.AsyncConfig{}) //nolint:exhaustruct_v5
+	logger := slog.New(h).With("app", "test").WithGroup("req")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	logger.InfoContext(ctx, "message", "id", 1)
+	cancel()
+
+	require.NoError(t, h.Flush(t.Context()))
+	assert.Contains(t, buf.String(), "msg=message app=test req.id=1\n")
+
+	require.NoError(t, h.Close(t.Context()))
+	require.NoError(t, h.Flush(t.Context()))
+}
+
+func TestAsyncHandlerCloseTimeout(t *testing.T) {
+	w := newGateWriter()
+	h := slogkit.NewAsyncHandler(slog.NewTextHandler(w, nil), slogkit.AsyncConfig{}) //nolint:exhaustruct_v5
+
+	slog.New(h).Info("message")
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, h.Close(ctx), context.DeadlineExceeded)
+
+	close(w.gate)
+	require.NoError(t, h.Close(t.Context()))
+	assert.Equal(t, []string{"message"}, w.Messages())
+}
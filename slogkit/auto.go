@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+)
+
+// AutoEnv describes the output writer of a [HandlerAuto] logger for an [AutoPolicy].
+type AutoEnv struct {
+	// File is whether the writer is an [*os.File].
+	File bool
+	// Terminal is whether the writer is a terminal, including Cygwin and MSYS terminals.
+	Terminal bool
+	// Journal is whether the writer is the stream connected to systemd-journald, as announced by
+	// the JOURNAL_STREAM environment variable, and the journald socket is available.
+	Journal bool
+	// PreferJSON is whether JSON was requested for non-terminals with [WithAutoJSON].
+	PreferJSON bool
+}
+
+// AutoPolicy selects the slogkit handler used by [HandlerAuto] for an output writer.
+// Returning [HandlerAuto], or [HandlerJournal] if the journald stream was not detected,
+// selects [HandlerText]. If the policy selects [HandlerTint] and NO_COLOR is set or CLICOLOR is
+// "0", colors are disabled as with [WithNoColor], unless FORCE_COLOR or CLICOLOR_FORCE force them.
+type AutoPolicy func(env AutoEnv) Handler
+
+// DefaultAutoPolicy is the default [AutoPolicy]. For file writers, the first matching row of the
+// following table selects the handler:
+//
+//	Condition                                                  Handler
+//	---------------------------------------------------------  --------------
+//	Journal                                                    HandlerJournal
+//	FORCE_COLOR or CLICOLOR_FORCE set and not "0"              HandlerTint
+//	Terminal                                                   HandlerTint
+//	CI set, and neither NO_COLOR set nor CLICOLOR is "0"       HandlerTint
+//	KUBERNETES_SERVICE_HOST set or PreferJSON                  HandlerJSON
+//	Otherwise                                                  HandlerText
+//
+// Environment variables are considered set if they are not empty. Other writers use
+// [HandlerJSON] if PreferJSON or [HandlerText] otherwise. On terminals with NO_COLOR set or
+// CLICOLOR "0", [HandlerTint] keeps its format without colors (see [AutoPolicy]).
+func DefaultAutoPolicy(env AutoEnv) Handler {
+	if !env.File {
+		return autoFallback(env.PreferJSON)
+	}
+
+	switch {
+	case env.Journal:
+		return HandlerJournal
+	case envForceColor():
+		return HandlerTint
+	case env.Terminal:
+		return HandlerTint
+	case os.Getenv("CI") != "" && !envNoColor():
+		return HandlerTint
+	default:
+		return autoFallback(env.PreferJSON || os.Getenv("KUBERNETES_SERVICE_HOST") != "")
+	}
+}
+
+// WithAutoPolicy sets the policy used by [HandlerAuto] to select a handler,
+// [DefaultAutoPolicy] by default.
+func WithAutoPolicy(policy AutoPolicy) Option {
+	return func(o *options) {
+		o.autoPolicy = policy
+	}
+}
+
+// WithAutoJSON makes [HandlerAuto] prefer [HandlerJSON] instead of [HandlerText] for writers
+// that are not terminals (see [AutoEnv.PreferJSON]).
+func WithAutoJSON(enabled bool) Option {
+	return func(o *options) {
+		o.autoJSON = enabled
+	}
+}
+
+// autoHandler returns the handler selected by the auto policy for writer and the writer to use.
+func (o *options) autoHandler(writer io.Writer) (Handler, io.Writer) {
+	env := AutoEnv{File: false, Terminal: false, Journal: false, PreferJSON: o.autoJSON}
+
+	f, ok := writer.(*os.File)
+	if ok {
+		env.File = true
+		env.Terminal = isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+	}
+
+	var jw *JournalWriter
+	if ok && isJournalStream(f) {
+		if w, err := sharedJournalWriter(); err == nil {
+			env.Journal = true
+			jw = w
+		}
+	}
+
+	handler := o.autoPolicy(env)
+
+	switch handler { //nolint:exhaustive // Other handlers use the writer as is.
+	case HandlerAuto:
+		return HandlerText, writer
+	case HandlerJournal:
+		if jw == nil {
+			return HandlerText, writer
+		}
+
+		return handler, jw
+	case HandlerTint:
+		if envNoColor() && !envForceColor() {
+			o.noColor = true
+		}
+
+		if ok {
+			return handler, colorable.NewColorable(f)
+		}
+	}
+
+	return handler, writer
+}
+
+// autoFallback returns the handler for writers that are not terminals.
+func autoFallback(json bool) Handler {
+	if json {
+		return HandlerJSON
+	}
+
+	return HandlerText
+}
+
+// envNoColor reports whether colors are disabled by the NO_COLOR or CLICOLOR environment variables.
+func envNoColor() bool {
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("CLICOLOR") == "0"
+}
+
+// envForceColor reports whether colors are forced by the FORCE_COLOR or CLICOLOR_FORCE environment
+// variables.
+func envForceColor() bool {
+	return envForced("FORCE_COLOR") || envForced("CLICOLOR_FORCE")
+}
+
+// envForced reports whether the environment variable key is set and not "0".
+func envForced(key string) bool {
+	v := os.Getenv(key)
+
+	return v != "" && v != "0"
+}
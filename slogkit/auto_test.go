@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearAutoEnv unsets the environment variables read by [slogkit.DefaultAutoPolicy].
+func clearAutoEnv(t *testing.T) {
+	t.Helper()
+
+	for _, key := range []string{
+		"NO_COLOR", "FORCE_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "CI", "KUBERNETES_SERVICE_HOST", "JOURNAL_STREAM",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestDefaultAutoPolicy(t *testing.T) {
+	file := slogkit.AutoEnv{File: true, Terminal: false, Journal: false, PreferJSON: false}
+	term := slogkit.AutoEnv{File: true, Terminal: true, Journal: false, PreferJSON: false}
+	journal := slogkit.AutoEnv{File: true, Terminal: false, Journal: true, PreferJSON: false}
+	fileJSON := slogkit.AutoEnv{File: true, Terminal: false, Journal: false, PreferJSON: true}
+	buffer := slogkit.AutoEnv{File: false, Terminal: false, Journal: false, PreferJSON: false}
+	bufferJSON := slogkit.AutoEnv{File: false, Terminal: false, Journal: false, PreferJSON: true}
+
+	testCases := []struct {
+		name string
+		env  slogkit.AutoEnv
+		vars map[string]string
+		want slogkit.Handler
+	}{
+		{name: "File", env: file, vars: nil, want: slogkit.HandlerText},
+		{name: "FileJSON", env: fileJSON, vars: nil, want: slogkit.HandlerJSON},
+		{name: "Terminal", env: term, vars: nil, want: slogkit.HandlerTint},
+		{name: "TerminalNoColor", env: term, vars: map[string]string{"NO_COLOR": "1"}, want: slogkit.HandlerTint},
+		{name: "TerminalCliColor0", env: term, vars: map[string]string{"CLICOLOR": "0"}, want: slogkit.HandlerTint},
+		{name: "TerminalCliColor1", env: term, vars: map[string]string{"CLICOLOR": "1"}, want: slogkit.HandlerTint},
+		{name: "ForceColor", env: file, vars: map[string]string{"FORCE_COLOR": "1"}, want: slogkit.HandlerTint},
+		{name: "ForceColor0", env: file, vars: map[string]string{"FORCE_COLOR": "0"}, want: slogkit.HandlerText},
+		{name: "CliColorForce", env: file, vars: map[string]string{"CLICOLOR_FORCE": "1"}, want: slogkit.HandlerTint},
+		{
+			name: "ForceColorOverNoColor",
+			env:  term,
+			vars: map[string]string{"FORCE_COLOR": "true", "NO_COLOR": "1"},
+			want: slogkit.HandlerTint,
+		},
+		{name: "CI", env: file, vars: map[string]string{"CI": "true"}, want: slogkit.HandlerTint},
+		{name: "CINoColor", env: file, vars: map[string]string{"CI": "true", "NO_COLOR": "1"}, want: slogkit.HandlerText},
+		{
+			name: "Kubernetes",
+			env:  file,
+			vars: map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"},
+			want: slogkit.HandlerJSON,
+		},
+		{
+			name: "KubernetesTerminal",
+			env:  term,
+			vars: map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"},
+			want: slogkit.HandlerTint,
+		},
+		{name: "Journal", env: journal, vars: map[string]string{"FORCE_COLOR": "1"}, want: slogkit.HandlerJournal},
+		{name: "Buffer", env: buffer, vars: map[string]string{"FORCE_COLOR": "1", "CI": "1"}, want: slogkit.HandlerText},
+		{name: "BufferJSON", env: bufferJSON, vars: nil, want: slogkit.HandlerJSON},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			clearAutoEnv(t)
+
+			for k, v := range tCase.vars {
+				t.Setenv(k, v)
+			}
+
+			assert.Equal(t, tCase.want, slogkit.DefaultAutoPolicy(tCase.env))
+		})
+	}
+}
+
+func TestHandlerAutoPolicy(t *testing.T) {
+	clearAutoEnv(t)
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var got slogkit.AutoEnv
+
+	logger := slogkit.NewLoggerWithOptions(f, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoJSON(true),
+		slogkit.WithAutoPolicy(func(env slogkit.AutoEnv) slogkit.Handler {
+			got = env
+
+			return slogkit.DefaultAutoPolicy(env)
+		}),
+	)
+	logger.Info("message")
+
+	assert.Equal(t, slogkit.AutoEnv{File: true, Terminal: false, Journal: false, PreferJSON: true}, got)
+
+	b, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Regexp(t, `^{"ts":"\S+","level":"INFO","msg":"message"}\n$`, string(b))
+}
+
+func TestHandlerAutoForceColor(t *testing.T) {
+	clearAutoEnv(t)
+	t.Setenv("FORCE_COLOR", "1")
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	slogkit.NewLogger(f, slogkit.HandlerAuto, slog.LevelInfo).Warn("message")
+
+	b, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Regexp(t, `\x1b\[93mWRN\x1b\[0m message\n$`, string(b))
+}
+
+func TestHandlerAutoNoColor(t *testing.T) {
+	clearAutoEnv(t)
+	t.Setenv("NO_COLOR", "1")
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	// Disabling colors keeps the format of the tint handler, as on a terminal.
+	slogkit.NewLoggerWithOptions(f, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoPolicy(func(slogkit.AutoEnv) slogkit.Handler { return slogkit.HandlerTint }),
+	).Warn("message", "key", "val")
+
+	b, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Regexp(t, `^[^\x1b]+ WRN message key=val\n$`, string(b))
+}
+
+func TestHandlerAutoInvalidPolicy(t *testing.T) {
+	var buf bytes.Buffer
+
+	slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoPolicy(func(slogkit.AutoEnv) slogkit.Handler { return slogkit.HandlerJournal }),
+	).Info("message")
+	assert.Regexp(t, `^ts=\S+ level=INFO msg=message\n$`, buf.String())
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoPolicy(func(slogkit.AutoEnv) slogkit.Handler { return -1 }),
+	)
+	assert.Nil(t, logger)
+}
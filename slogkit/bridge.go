@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Attribute keys used by the [NewHTTPErrorLog] bridge.
+const (
+	RemoteAddrKey = "remote_addr"
+	ErrorKey      = "err"
+)
+
+// lineParser parses a line written to a standard logger into a message and attributes.
+// It returns false if the line is not recognized.
+type lineParser func(line string) (slog.Level, string, []slog.Attr, bool)
+
+// logWriter is an [io.Writer] that logs each written line as a record.
+type logWriter struct {
+	logger *slog.Logger
+	level  slog.Level
+	parse  lineParser
+}
+
+// NewLogWriter creates an [io.Writer] that logs each line written to it as a record with the
+// line as message, at the specified level using logger. Empty lines and line terminators are
+// removed. Each write must consist of complete lines, as those of a [log.Logger].
+// It can be used as the output of a [log.Logger] created with no flags. The source of the records
+// is the first caller outside of the [log] package.
+func NewLogWriter(logger *slog.Logger, level slog.Level) io.Writer {
+	return &logWriter{logger: logger, level: level, parse: nil}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	ctx := context.Background()
+
+	text := string(bytes.TrimRight(p, "\r\n"))
+
+	// Parsed writes are logged as a single record, as parsers handle multi-line messages.
+	lines := []string{text}
+	if w.parse == nil {
+		lines = strings.Split(text, "\n")
+	}
+
+	var pc uintptr
+
+	for _, line := range lines {
+		level, msg, attrs := w.level, strings.TrimSuffix(line, "\r"), []slog.Attr(nil)
+
+		if w.parse != nil {
+			if lvl, m, as, ok := w.parse(line); ok {
+				level, msg, attrs = lvl, m, as
+			}
+		} else if msg == "" {
+			continue
+		}
+
+		if !w.logger.Enabled(ctx, level) {
+			continue
+		}
+
+		if pc == 0 {
+			// Skip Callers, callerPC and Write, then the frames of the log package.
+			pc = callerPC(3, "log.") //nolint:mnd
+		}
+
+		r := slog.NewRecord(time.Now(), level, msg, pc)
+		r.AddAttrs(attrs...)
+
+		if err := w.logger.Handler().Handle(ctx, r); err != nil {
+			return len(p), err //nolint:wrapcheck // Transparent writer.
+		}
+	}
+
+	return len(p), nil
+}
+
+// callerPC returns the program counter of the first caller, after skipping skip frames
+// (see [runtime.Callers]), that is not a function with the given prefix, or zero if there is none.
+func callerPC(skip int, prefix string) uintptr {
+	var pcs [32]uintptr
+
+	n := runtime.Callers(skip, pcs[:])
+
+	for _, pc := range pcs[:n] {
+		// The outermost frame of a program counter is the function it belongs to,
+		// any other frames are inlined calls.
+		var function string
+
+		frames := runtime.CallersFrames([]uintptr{pc})
+		for more := true; more; {
+			var frame runtime.Frame
+
+			frame, more = frames.Next()
+			function = frame.Function
+		}
+
+		if !strings.HasPrefix(function, prefix) {
+			return pc
+		}
+	}
+
+	return 0
+}
+
+// RedirectStdLog redirects the output of the standard logger of the [log] package
+// (see [log.Default]) to logger, logging each line at the specified level.
+// It returns a function that restores the previous output, flags and prefix of the standard logger.
+// It returns [ErrStdLogLoop] if logger uses the default handler of [log/slog], which writes to
+// the standard logger, for example [slog.Default] when [slog.SetDefault] has not been called.
+func RedirectStdLog(logger *slog.Logger, level slog.Level) (func(), error) {
+	if isSlogDefaultHandler(logger.Handler()) {
+		return nil, ErrStdLogLoop
+	}
+
+	std := log.Default()
+	out, flags, prefix := std.Writer(), std.Flags(), std.Prefix()
+
+	std.SetOutput(NewLogWriter(logger, level))
+	std.SetFlags(0)
+	std.SetPrefix("")
+
+	return func() {
+		std.SetOutput(out)
+		std.SetFlags(flags)
+		std.SetPrefix(prefix)
+	}, nil
+}
+
+// isSlogDefaultHandler reports whether h is the default handler of [log/slog], which is unexported.
+func isSlogDefaultHandler(h slog.Handler) bool {
+	return fmt.Sprintf("%T", h) == "*slog.defaultHandler"
+}
+
+// httpErrorPatterns match the errors logged by [net/http] servers and their attributes.
+//
+//nolint:gochecknoglobals // Read-only table of compiled patterns.
+var httpErrorPatterns = []struct {
+	pattern *regexp.Regexp
+	keys    []string
+	level   slog.Level // overrides the level of the error log unless it is zero (info)
+}{
+	{
+		pattern: regexp.MustCompile(`^(http: TLS handshake error) from (\S+): (.*)$`),
+		keys:    []string{RemoteAddrKey, ErrorKey},
+		level:   0,
+	},
+	{
+		pattern: regexp.MustCompile(`(?s)^(http: panic serving) (\S+): ([^\n]*)\n?(.*)$`),
+		keys:    []string{RemoteAddrKey, PanicKey, StackKey},
+		level:   slog.LevelError,
+	},
+	{
+		pattern: regexp.MustCompile(`^(http: Accept error): (.*); retrying in (\S+)$`),
+		keys:    []string{ErrorKey, "retry_in"},
+		level:   0,
+	},
+	{
+		pattern: regexp.MustCompile(`^(http2: server: error reading preface) from client (\S+): (.*)$`),
+		keys:    []string{RemoteAddrKey, ErrorKey},
+		level:   0,
+	},
+	{
+		pattern: regexp.MustCompile(`^(http: superfluous response.WriteHeader call) from (.*)$`),
+		keys:    []string{"caller"},
+		level:   0,
+	},
+	{
+		pattern: regexp.MustCompile(`^(http: [^:]+): (.*)$`),
+		keys:    []string{ErrorKey},
+		level:   0,
+	},
+}
+
+// NewHTTPErrorLog creates a [log.Logger] for [net/http.Server.ErrorLog] that logs the server
+// errors using logger at the specified level. Known errors are parsed into a message and
+// attributes, for example "http: TLS handshake error from 10.0.0.1:5678: EOF" is logged as the
+// message "http: TLS handshake error" with the attributes remote_addr=10.0.0.1:5678 and err=EOF.
+// Panics in handlers ("http: panic serving") are always logged at [slog.LevelError].
+func NewHTTPErrorLog(logger *slog.Logger, level slog.Level) *log.Logger {
+	w := &logWriter{logger: logger, level: level, parse: func(line string) (slog.Level, string, []slog.Attr, bool) {
+		for _, p := range httpErrorPatterns {
+			m := p.pattern.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+
+			attrs := make([]slog.Attr, len(p.keys))
+			for i, key := range p.keys {
+				attrs[i] = slog.String(key, m[i+2])
+			}
+
+			lvl := level
+			if p.level != 0 {
+				lvl = p.level
+			}
+
+			return lvl, m[1], attrs, true
+		}
+
+		return 0, "", nil, false
+	}}
+
+	return log.New(w, "", 0)
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/hhromic/go-toolkit/slogkit/slogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerText, slog.LevelInfo, slogkit.WithSource(true))
+	std := log.New(slogkit.NewLogWriter(logger, slog.LevelWarn), "", 0)
+
+	_, file, line, _ := runtime.Caller(0)
+	std.Printf("hello %s", "world")
+
+	assert.Regexp(t, `^ts=\S+ level=WARN source=`+file+`:`+strconv.Itoa(line+1)+` msg="hello world"\n$`,
+		buf.String())
+
+	buf.Reset()
+
+	std = log.New(slogkit.NewLogWriter(logger, slog.LevelDebug), "", 0)
+	std.Print("disabled")
+	assert.Empty(t, buf.String())
+}
+
+func TestNewLogWriterLines(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	w := slogkit.NewLogWriter(slog.New(h), slog.LevelInfo)
+
+	n, err := w.Write([]byte("first\r\n\nsecond\nthird\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 21, n)
+
+	h.AssertLogged(t, slog.LevelInfo, "first")
+	h.AssertLogged(t, slog.LevelInfo, "second")
+	h.AssertLogged(t, slog.LevelInfo, "third")
+	assert.Equal(t, 3, h.Len())
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+
+	restore, err := slogkit.RedirectStdLog(slog.New(h), slog.LevelInfo)
+	require.NoError(t, err)
+	log.Println("from std log")
+	restore()
+
+	h.AssertLogged(t, slog.LevelInfo, "from std log")
+	assert.Equal(t, log.LstdFlags, log.Flags())
+	assert.Equal(t, 1, h.Len())
+}
+
+func TestRedirectStdLogDefault(t *testing.T) {
+	out := log.Writer()
+
+	for _, logger := range []*slog.Logger{slog.Default(), slog.Default().With("key", "val")} {
+		restore, err := slogkit.RedirectStdLog(logger, slog.LevelInfo)
+		require.ErrorIs(t, err, slogkit.ErrStdLogLoop)
+		assert.Nil(t, restore)
+		assert.Equal(t, out, log.Writer())
+	}
+}
+
+func TestNewHTTPErrorLog(t *testing.T) {
+	testCases := []struct {
+		name      string
+		line      string
+		wantLevel slog.Level
+		wantMsg   string
+		wantArgs  []any
+	}{
+		{
+			name:      "TLSHandshake",
+			line:      "http: TLS handshake error from 10.0.0.1:5678: EOF",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "http: TLS handshake error",
+			wantArgs:  []any{"remote_addr", "10.0.0.1:5678", "err", "EOF"},
+		},
+		{
+			name:      "Panic",
+			line:      "http: panic serving 10.0.0.1:5678: boom\ngoroutine 1 [running]:\nmain.main()\n",
+			wantLevel: slog.LevelError,
+			wantMsg:   "http: panic serving",
+			wantArgs:  []any{"remote_addr", "10.0.0.1:5678", "panic", "boom", "stack", "goroutine 1 [running]:\nmain.main()"},
+		},
+		{
+			name:      "Accept",
+			line:      "http: Accept error: accept tcp [::]:80: too many open files; retrying in 5ms",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "http: Accept error",
+			wantArgs:  []any{"err", "accept tcp [::]:80: too many open files", "retry_in", "5ms"},
+		},
+		{
+			name:      "HTTP2Preface",
+			line:      "http2: server: error reading preface from client 10.0.0.1:5678: timeout",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "http2: server: error reading preface",
+			wantArgs:  []any{"remote_addr", "10.0.0.1:5678", "err", "timeout"},
+		},
+		{
+			name:      "SuperfluousWriteHeader",
+			line:      "http: superfluous response.WriteHeader call from main.handler (main.go:12)",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "http: superfluous response.WriteHeader call",
+			wantArgs:  []any{"caller", "main.handler (main.go:12)"},
+		},
+		{
+			name:      "OtherHTTP",
+			line:      "http: proxy error: dial tcp: connection refused",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "http: proxy error",
+			wantArgs:  []any{"err", "dial tcp: connection refused"},
+		},
+		{
+			name:      "Unknown",
+			line:      "something else",
+			wantLevel: slog.LevelWarn,
+			wantMsg:   "something else",
+			wantArgs:  nil,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			h := slogtest.NewHandler(nil)
+
+			slogkit.NewHTTPErrorLog(slog.New(h), slog.LevelWarn).Print(tCase.line)
+			h.AssertLogged(t, tCase.wantLevel, tCase.wantMsg, tCase.wantArgs...)
+		})
+	}
+}
+
+func TestNewHTTPErrorLogServer(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.Config.ErrorLog = slogkit.NewHTTPErrorLog(slog.New(h), slog.LevelWarn)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("not a TLS handshake\r\n\r\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	rec := h.WaitLogged(t, 5*time.Second, slog.LevelWarn, "http: TLS handshake error")
+	assert.Equal(t, conn.LocalAddr().String(), rec.Attrs["remote_addr"].String())
+	assert.NotEmpty(t, rec.Attrs["err"].String())
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// Attribute keys of trace context used by [HandlerGCP] and [HandlerECS].
+const (
+	TraceIDKey      = "trace_id"
+	SpanIDKey       = "span_id"
+	TraceSampledKey = "trace_sampled"
+)
+
+// ECSVersion is the Elastic Common Schema version of the records of [HandlerECS].
+const ECSVersion = "8.11.0"
+
+// Field names of Google Cloud Logging structured logs.
+const (
+	gcpSourceLocationKey = "logging.googleapis.com/sourceLocation"
+	gcpTraceKey          = "logging.googleapis.com/trace"
+	gcpSpanIDKey         = "logging.googleapis.com/spanId"
+	gcpTraceSampledKey   = "logging.googleapis.com/trace_sampled"
+)
+
+// newGCPHandler creates the slog Handler of [HandlerGCP].
+func newGCPHandler(writer io.Writer, leveler slog.Leveler, o *options) slog.Handler {
+	oo := *o
+	oo.timeKey = slog.TimeKey
+
+	opts := oo.handlerOptions(leveler)
+	opts.ReplaceAttr = gcpReplaceAttr(opts.ReplaceAttr, o.gcpProjectID)
+
+	return slog.NewJSONHandler(writer, opts)
+}
+
+// newECSHandler creates the slog Handler of [HandlerECS].
+func newECSHandler(writer io.Writer, leveler slog.Leveler, o *options) slog.Handler {
+	oo := *o
+	oo.timeKey = "@timestamp"
+
+	opts := oo.handlerOptions(leveler)
+	opts.ReplaceAttr = ecsReplaceAttr(opts.ReplaceAttr)
+
+	return slog.NewJSONHandler(writer, opts).WithAttrs([]slog.Attr{slog.String("ecs.version", ECSVersion)})
+}
+
+func gcpReplaceAttr(fn ReplaceAttrFunc, projectID string) ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if a = fn(groups, a); a.Key == "" || len(groups) != 0 {
+			return a
+		}
+
+		switch a.Key {
+		case slog.LevelKey:
+			if lvl, ok := builtinLevel(groups, a); ok {
+				return slog.String("severity", gcpSeverity(lvl))
+			}
+		case slog.MessageKey:
+			a.Key = "message"
+		case slog.SourceKey:
+			if src, ok := a.Value.Any().(*slog.Source); ok {
+				return slog.Group(gcpSourceLocationKey,
+					slog.String("file", src.File),
+					slog.String("line", strconv.Itoa(src.Line)),
+					slog.String("function", src.Function),
+				)
+			}
+		case TraceIDKey:
+			trace := a.Value.String()
+			if projectID != "" && !strings.HasPrefix(trace, "projects/") {
+				trace = "projects/" + projectID + "/traces/" + trace
+			}
+
+			return slog.String(gcpTraceKey, trace)
+		case SpanIDKey:
+			a.Key = gcpSpanIDKey
+		case TraceSampledKey:
+			a.Key = gcpTraceSampledKey
+		}
+
+		return a
+	}
+}
+
+// gcpSeverity returns the Google Cloud Logging severity of level.
+func gcpSeverity(level slog.Level) string {
+	switch {
+	case level >= LevelFatal:
+		return "CRITICAL"
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= LevelNotice:
+		return "NOTICE"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+func ecsReplaceAttr(fn ReplaceAttrFunc) ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if a = fn(groups, a); a.Key == "" || len(groups) != 0 {
+			return a
+		}
+
+		switch a.Key {
+		case slog.LevelKey:
+			if lvl, ok := builtinLevel(groups, a); ok {
+				return slog.String("log.level", strings.ToLower(LevelName(lvl)))
+			}
+		case slog.MessageKey:
+			a.Key = "message"
+		case slog.SourceKey:
+			if src, ok := a.Value.Any().(*slog.Source); ok {
+				return slog.Group("log.origin",
+					slog.String("file.name", src.File),
+					slog.Int("file.line", src.Line),
+					slog.String("function", src.Function),
+				)
+			}
+		case TraceIDKey:
+			a.Key = "trace.id"
+		case SpanIDKey:
+			a.Key = "span.id"
+		}
+
+		if err, ok := a.Value.Any().(error); ok && (a.Key == "error" || a.Key == "err") {
+			attrs := []any{
+				slog.String("message", err.Error()),
+				slog.String("type", fmt.Sprintf("%T", err)),
+			}
+			if stack := errStack(err); stack != "" {
+				attrs = append(attrs, slog.String("stack_trace", stack))
+			}
+
+			return slog.Group("error", attrs...)
+		}
+
+		return a
+	}
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals // Test flag.
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares got with the contents of the golden file testdata/name.golden.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, fs.FileMode(0o644)))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+// logGolden logs a fixed set of records with the given handler.
+func logGolden(t *testing.T, handler slogkit.Handler, opts ...slogkit.Option) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, handler, slogkit.LevelTrace, opts...)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 678000000, time.UTC)
+
+	for _, lvl := range []slog.Level{
+		slogkit.LevelTrace, slog.LevelDebug, slog.LevelInfo, slogkit.LevelNotice,
+		slog.LevelWarn, slog.LevelError, slogkit.LevelFatal,
+	} {
+		r := slog.NewRecord(ts, lvl, "level "+slogkit.LevelName(lvl), 0)
+		require.NoError(t, logger.Handler().Handle(t.Context(), r))
+	}
+
+	r := slog.NewRecord(ts, slog.LevelInfo, "request served", 0)
+	r.AddAttrs(
+		slog.String(slogkit.TraceIDKey, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		slog.String(slogkit.SpanIDKey, "00f067aa0ba902b7"),
+		slog.Bool(slogkit.TraceSampledKey, true),
+		slog.Group("http", slog.Int("status", 200), slog.String("path", "/api")),
+	)
+	require.NoError(t, logger.With("app", "test").Handler().Handle(t.Context(), r))
+
+	r = slog.NewRecord(ts, slog.LevelError, "request failed", 0)
+	r.AddAttrs(slog.Any("error", errors.New("connection refused")))
+	require.NoError(t, logger.Handler().Handle(t.Context(), r))
+
+	return buf.Bytes()
+}
+
+func TestHandlerGCP(t *testing.T) {
+	assertGolden(t, "gcp", logGolden(t, slogkit.HandlerGCP))
+	assertGolden(t, "gcp_project", logGolden(t, slogkit.HandlerGCP, slogkit.WithGCPProjectID("my-project")))
+
+	t.Run("Source", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerGCP, slog.LevelInfo, slogkit.WithSource(true))
+		_, file, line, _ := runtime.Caller(0)
+		logger.Info("message")
+
+		assert.Contains(t, buf.String(), `"logging.googleapis.com/sourceLocation":{"file":"`+file+
+			`","line":"`+strconv.Itoa(line+1)+`","function":"github.com/hhromic/go-toolkit/slogkit_test.TestHandlerGCP.func1"}`)
+	})
+}
+
+func TestHandlerECS(t *testing.T) {
+	assertGolden(t, "ecs", logGolden(t, slogkit.HandlerECS))
+
+	t.Run("Source", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerECS, slog.LevelInfo, slogkit.WithSource(true))
+		_, file, line, _ := runtime.Caller(0)
+		logger.Info("message")
+
+		assert.Contains(t, buf.String(), `"log.origin":{"file.name":"`+file+
+			`","file.line":`+strconv.Itoa(line+1)+`,"function":"github.com/hhromic/go-toolkit/slogkit_test.TestHandlerECS.func1"}`)
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLogger(&buf, slogkit.HandlerECS, slog.LevelInfo)
+		logger.Error("request failed", "err", slogkit.Errorf("connection refused"))
+
+		var entry struct {
+			Error struct {
+				Message    string `json:"message"`
+				StackTrace string `json:"stack_trace"`
+			} `json:"error"`
+		}
+
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "connection refused", entry.Error.Message)
+		assert.True(t, strings.HasPrefix(entry.Error.StackTrace,
+			"github.com/hhromic/go-toolkit/slogkit_test.TestHandlerECS.func2\n\t"), entry.Error.StackTrace)
+	})
+}
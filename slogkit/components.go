@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// ComponentKey is the attribute key that identifies the component of a log record.
+const ComponentKey = "component"
+
+// LevelSpec is a set of minimum logging levels for hierarchical components, for example
+// "http" or "db.pool", with a default level for everything else.
+type LevelSpec struct {
+	// Default is the minimum level for components without a specific level.
+	Default slog.Level
+	// Components maps component names to their minimum level.
+	Components map[string]slog.Level
+}
+
+// ParseLevelSpec parses a level spec string as accepted by [LevelSpec.UnmarshalText].
+func ParseLevelSpec(s string) (LevelSpec, error) {
+	var spec LevelSpec
+
+	err := spec.UnmarshalText([]byte(s))
+	if err != nil {
+		return LevelSpec{}, err
+	}
+
+	return spec, nil
+}
+
+// Level returns the minimum level for component. Components are dot-separated hierarchies and
+// the longest matching prefix wins, for example "db.pool.conn" uses the level of "db.pool" if
+// it is set, or otherwise the level of "db", or otherwise the default level.
+func (s LevelSpec) Level(component string) slog.Level {
+	for c := component; c != ""; {
+		if l, ok := s.Components[c]; ok {
+			return l
+		}
+
+		i := strings.LastIndexByte(c, '.')
+		if i < 0 {
+			break
+		}
+
+		c = c[:i]
+	}
+
+	return s.Default
+}
+
+// Min returns the lowest level of the spec.
+func (s LevelSpec) Min() slog.Level {
+	lvl := s.Default
+	for _, l := range s.Components {
+		lvl = min(lvl, l)
+	}
+
+	return lvl
+}
+
+// MarshalText implements [encoding.TextMarshaler] for a level spec.
+// The output format is "default,component=level,..." with components sorted by name
+// and levels named by [LevelName].
+// This function never returns errors.
+func (s LevelSpec) MarshalText() ([]byte, error) {
+	out := []byte(LevelName(s.Default))
+
+	for _, c := range slices.Sorted(maps.Keys(s.Components)) {
+		out = append(out, ',')
+		out = append(out, c...)
+		out = append(out, '=')
+		out = append(out, LevelName(s.Components[c])...)
+	}
+
+	return out, nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] for a level spec.
+// It accepts a comma-separated list of "component=level" entries and at most one bare level
+// entry for the default level, which is [slog.LevelInfo] if omitted. Levels are parsed with
+// [ParseLevel], therefore the custom slogkit levels are also accepted.
+// For example "info,http=debug,db.pool=warn".
+func (s *LevelSpec) UnmarshalText(b []byte) error {
+	spec := LevelSpec{Default: slog.LevelInfo, Components: map[string]slog.Level{}}
+	hasDefault := false
+
+	if len(bytes.TrimSpace(b)) > 0 {
+		for entry := range strings.SplitSeq(string(b), ",") {
+			entry = strings.TrimSpace(entry)
+
+			comp, lvlStr, found := strings.Cut(entry, "=")
+			if !found {
+				comp, lvlStr = "", entry
+			}
+
+			lvl, err := ParseLevel(lvlStr)
+			if err != nil {
+				return fmt.Errorf("%q: unmarshal level: %w", entry, err)
+			}
+
+			comp = strings.TrimSpace(comp)
+
+			switch {
+			case !found && hasDefault:
+				return fmt.Errorf("%q: duplicate default level: %w", entry, ErrInvalidLevelSpec)
+			case !found:
+				spec.Default, hasDefault = lvl, true
+			case comp == "":
+				return fmt.Errorf("%q: empty component: %w", entry, ErrInvalidLevelSpec)
+			default:
+				spec.Components[comp] = lvl
+			}
+		}
+	}
+
+	*s = spec
+
+	return nil
+}
+
+// ComponentLevels holds a [LevelSpec] that can be swapped at runtime without rebuilding loggers.
+// It implements [slog.Leveler] with the lowest level of the spec. When a *ComponentLevels is
+// given as leveler to [NewLogger] or [NewLoggerWithOptions], the logger applies per-component
+// levels as described in [NewComponentHandler]. A ComponentLevels is safe for concurrent use.
+type ComponentLevels struct {
+	spec atomic.Pointer[LevelSpec]
+}
+
+// NewComponentLevels creates a [ComponentLevels] with the given initial spec.
+func NewComponentLevels(spec LevelSpec) *ComponentLevels {
+	c := &ComponentLevels{} //nolint:exhaustruct_v5 // Set below.
+	c.Set(spec)
+
+	return c
+}
+
+// Level implements [slog.Leveler] by returning the lowest level of the current spec.
+func (c *ComponentLevels) Level() slog.Level {
+	return c.load().Min()
+}
+
+// ComponentLevel returns the minimum level for component using the current spec.
+func (c *ComponentLevels) ComponentLevel(component string) slog.Level {
+	return c.load().Level(component)
+}
+
+// Spec returns a copy of the current spec.
+func (c *ComponentLevels) Spec() LevelSpec {
+	s := c.load()
+
+	return LevelSpec{Default: s.Default, Components: maps.Clone(s.Components)}
+}
+
+// Set atomically replaces the current spec with a copy of spec.
+func (c *ComponentLevels) Set(spec LevelSpec) {
+	spec.Components = maps.Clone(spec.Components)
+	c.spec.Store(&spec)
+}
+
+// MarshalText implements [encoding.TextMarshaler] using [LevelSpec.MarshalText].
+func (c *ComponentLevels) MarshalText() ([]byte, error) {
+	return c.load().MarshalText()
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] using [LevelSpec.UnmarshalText]
+// and atomically replaces the current spec.
+func (c *ComponentLevels) UnmarshalText(b []byte) error {
+	var spec LevelSpec
+
+	err := spec.UnmarshalText(b)
+	if err != nil {
+		return err
+	}
+
+	c.Set(spec)
+
+	return nil
+}
+
+func (c *ComponentLevels) load() *LevelSpec {
+	if s := c.spec.Load(); s != nil {
+		return s
+	}
+
+	return &LevelSpec{Default: slog.LevelInfo, Components: nil}
+}
+
+// componentHandler is an slog Handler that filters records by component level.
+type componentHandler struct {
+	next      slog.Handler
+	levels    *ComponentLevels
+	component string // from a ComponentKey attribute
+	groups    string // dot-separated open groups
+}
+
+// NewComponentHandler creates an slog Handler that filters the records passed to next using
+// per-component minimum levels from levels. The component of a record is the value of the
+// [ComponentKey] attribute in the record or added with [slog.Logger.With], or otherwise the
+// dot-separated names of the groups opened with [slog.Logger.WithGroup]. The next handler must
+// not filter out records that levels would allow, for example by using levels as its leveler.
+func NewComponentHandler(next slog.Handler, levels *ComponentLevels) slog.Handler {
+	return &componentHandler{next: next, levels: levels, component: "", groups: ""}
+}
+
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minLevel := h.levels.Level() // records may still carry their own component attribute
+	if comp := h.knownComponent(); comp != "" {
+		minLevel = h.levels.ComponentLevel(comp)
+	}
+
+	return level >= minLevel && h.next.Enabled(ctx, level)
+}
+
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	comp := h.knownComponent()
+
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == ComponentKey && a.Value.Kind() == slog.KindString {
+			comp = a.Value.String()
+
+			return false
+		}
+
+		return true
+	})
+
+	if r.Level < h.levels.ComponentLevel(comp) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+
+	for _, a := range attrs {
+		if a.Key == ComponentKey && a.Value.Kind() == slog.KindString {
+			h2.component = a.Value.String()
+		}
+	}
+
+	return &h2
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+
+	if h2.groups == "" {
+		h2.groups = name
+	} else {
+		h2.groups += "." + name
+	}
+
+	return &h2
+}
+
+func (h *componentHandler) knownComponent() string {
+	if h.component != "" {
+		return h.component
+	}
+
+	return h.groups
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelSpecUnmarshalText(t *testing.T) {
+	testCases := []struct {
+		name    string
+		b       []byte
+		want    slogkit.LevelSpec
+		wantErr error
+	}{
+		{
+			name:    "Empty",
+			b:       []byte(""),
+			want:    slogkit.LevelSpec{Default: slog.LevelInfo, Components: map[string]slog.Level{}},
+			wantErr: nil,
+		},
+		{
+			name:    "DefaultOnly",
+			b:       []byte("debug"),
+			want:    slogkit.LevelSpec{Default: slog.LevelDebug, Components: map[string]slog.Level{}},
+			wantErr: nil,
+		},
+		{
+			name: "Components",
+			b:    []byte("info, http=debug,db.pool=warn"),
+			want: slogkit.LevelSpec{
+				Default:    slog.LevelInfo,
+				Components: map[string]slog.Level{"http": slog.LevelDebug, "db.pool": slog.LevelWarn},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "ComponentsNoDefault",
+			b:    []byte("http=error"),
+			want: slogkit.LevelSpec{
+				Default:    slog.LevelInfo,
+				Components: map[string]slog.Level{"http": slog.LevelError},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "DuplicateDefault",
+			b:       []byte("info,debug"),
+			want:    slogkit.LevelSpec{},
+			wantErr: slogkit.ErrInvalidLevelSpec,
+		},
+		{
+			name:    "EmptyComponent",
+			b:       []byte("info,=debug"),
+			want:    slogkit.LevelSpec{},
+			wantErr: slogkit.ErrInvalidLevelSpec,
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var spec slogkit.LevelSpec
+
+			err := spec.UnmarshalText(tCase.b)
+			require.ErrorIs(t, err, tCase.wantErr)
+
+			if tCase.wantErr == nil {
+				assert.Equal(t, tCase.want, spec)
+			}
+		})
+	}
+
+	t.Run("InvalidLevel", func(t *testing.T) {
+		_, err := slogkit.ParseLevelSpec("info,http=foo")
+		assert.ErrorContains(t, err, "http=foo")
+	})
+}
+
+func TestLevelSpecMarshalText(t *testing.T) {
+	spec, err := slogkit.ParseLevelSpec("db.pool=warn,info,http=debug")
+	require.NoError(t, err)
+
+	b, err := spec.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "INFO,db.pool=WARN,http=DEBUG", string(b))
+}
+
+func TestLevelSpecLevel(t *testing.T) {
+	spec, err := slogkit.ParseLevelSpec("info,db=error,db.pool=warn,db.pool.conn=debug")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		component string
+		want      slog.Level
+	}{
+		{component: "", want: slog.LevelInfo},
+		{component: "http", want: slog.LevelInfo},
+		{component: "db", want: slog.LevelError},
+		{component: "db.query", want: slog.LevelError},
+		{component: "db.pool", want: slog.LevelWarn},
+		{component: "db.pool.stats", want: slog.LevelWarn},
+		{component: "db.pool.conn.tls", want: slog.LevelDebug},
+		{component: "dbx", want: slog.LevelInfo},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.component, func(t *testing.T) {
+			assert.Equal(t, tCase.want, spec.Level(tCase.component))
+		})
+	}
+
+	assert.Equal(t, slog.LevelDebug, spec.Min())
+}
+
+func TestComponentHandler(t *testing.T) {
+	spec, err := slogkit.ParseLevelSpec("info,http=debug,db.pool=error")
+	require.NoError(t, err)
+
+	levels := slogkit.NewComponentLevels(spec)
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLogger(&buf, slogkit.HandlerText, levels)
+	httpLogger := logger.With(slogkit.ComponentKey, "http.server")
+	poolLogger := logger.WithGroup("db").WithGroup("pool")
+
+	lines := func() []string {
+		defer buf.Reset()
+
+		var out []string
+		for l := range strings.Lines(buf.String()) {
+			_, msg, _ := strings.Cut(l, "msg=")
+			out = append(out, strings.TrimSpace(msg))
+		}
+
+		return out
+	}
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	httpLogger.Debug("http debug")
+	poolLogger.Warn("pool warn")
+	poolLogger.Error("pool error")
+	logger.Debug("record debug", slogkit.ComponentKey, "http")
+	assert.Equal(t, []string{
+		"\"root info\"",
+		"\"http debug\" component=http.server",
+		"\"pool error\"",
+		"\"record debug\" component=http",
+	}, lines())
+
+	// Swap the spec at runtime without rebuilding loggers.
+	require.NoError(t, levels.UnmarshalText([]byte("warn,db=debug")))
+
+	logger.Info("root info")
+	httpLogger.Info("http info")
+	poolLogger.Debug("pool debug")
+	assert.Equal(t, []string{"\"pool debug\""}, lines())
+
+	b, err := levels.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "WARN,db=DEBUG", string(b))
+	assert.Equal(t, slog.LevelDebug, levels.Level())
+}
+
+func TestConfigComponents(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn,http=debug")
+
+	var buf bytes.Buffer
+
+	cfg := slogkit.NewConfig()
+	cfg.Writer = &buf
+	cfg.Handler = slogkit.HandlerText
+	require.NoError(t, cfg.LoadEnv(""))
+	assert.Equal(t, slog.LevelWarn, cfg.Level)
+	assert.Equal(t, map[string]slog.Level{"http": slog.LevelDebug}, cfg.Components)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	assert.Equal(t, "WARN,http=DEBUG", fs.Lookup(slogkit.FlagLogLevel).DefValue)
+
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+
+	logger.Info("root info")
+	logger.Debug("http debug", slogkit.ComponentKey, "http")
+	assert.NotContains(t, buf.String(), "root info")
+	assert.Contains(t, buf.String(), "http debug")
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Names of the flags registered by [Config.RegisterFlags].
+const (
+	FlagLogLevel   = "log-level"
+	FlagLogHandler = "log-handler"
+)
+
+// Names of the environment variables read by [Config.LoadEnv], without prefix.
+// EnvLogFormat is accepted as an alias of EnvLogHandler.
+const (
+	EnvLogLevel   = "LOG_LEVEL"
+	EnvLogHandler = "LOG_HANDLER"
+	EnvLogFormat  = "LOG_FORMAT"
+)
+
+// Config is a logging configuration that can be loaded from flags and environment variables.
+// Values set by flags take precedence over values loaded from the environment, which in turn
+// take precedence over the defaults set by [NewConfig], regardless of the call order.
+type Config struct {
+	// Level is the minimum logging level.
+	Level slog.Level
+	// Components are per-component minimum logging levels (see [LevelSpec]).
+	Components map[string]slog.Level
+	// Handler is the slogkit handler to use.
+	Handler Handler
+	// Writer is the output of the logger.
+	Writer io.Writer
+	// Options are additional options for [NewLoggerWithOptions].
+	Options []Option
+
+	fromFlags map[string]bool
+}
+
+// NewConfig creates a [Config] with default values: [slog.LevelInfo], [HandlerAuto]
+// and [os.Stderr] as output.
+func NewConfig() *Config {
+	return &Config{
+		Level:      slog.LevelInfo,
+		Components: nil,
+		Handler:    HandlerAuto,
+		Writer:     os.Stderr,
+		Options:    nil,
+		fromFlags:  map[string]bool{},
+	}
+}
+
+// RegisterFlags registers the [FlagLogLevel] and [FlagLogHandler] flags in fs.
+// The current values of the configuration are used as flag defaults.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(
+		&configFlag{name: FlagLogLevel, cfg: c, value: configLevelSpec{c}},
+		FlagLogLevel, "minimum logging level (trace, debug, info, notice, warn, error, fatal) or level spec (info,http=debug)",
+	)
+	fs.Var(
+		&configFlag{name: FlagLogHandler, cfg: c, value: &c.Handler},
+		FlagLogHandler, "logging handler (text, json, tint, auto, gcp, ecs, syslog, journal)",
+	)
+}
+
+// LoadEnv loads the configuration from the [EnvLogLevel] and [EnvLogHandler] environment
+// variables, each name preceded by prefix (for example "MYAPP_"). Unset or empty variables and
+// values already set by flags are ignored. Levels are parsed as a [LevelSpec].
+func (c *Config) LoadEnv(prefix string) error {
+	err := c.loadEnv(FlagLogLevel, configLevelSpec{c}, prefix+EnvLogLevel)
+	if err != nil {
+		return err
+	}
+
+	err = c.loadEnv(FlagLogHandler, &c.Handler, prefix+EnvLogHandler, prefix+EnvLogFormat)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Build creates an slog Logger using the configuration.
+// If there are per-component levels, the logger uses a [ComponentLevels] leveler.
+func (c *Config) Build() (*slog.Logger, error) {
+	var leveler slog.Leveler = c.Level
+	if len(c.Components) > 0 {
+		leveler = NewComponentLevels(LevelSpec{Default: c.Level, Components: c.Components})
+	}
+
+	logger := NewLoggerWithOptions(c.Writer, c.Handler, leveler, c.Options...)
+	if logger == nil {
+		return nil, fmt.Errorf("%v: %w", c.Handler, ErrUnknownHandlerName)
+	}
+
+	return logger, nil
+}
+
+func (c *Config) loadEnv(flagName string, value encoding.TextUnmarshaler, keys ...string) error {
+	if c.fromFlags[flagName] {
+		return nil
+	}
+
+	for _, key := range keys {
+		if str, ok := os.LookupEnv(key); ok && str != "" {
+			err := value.UnmarshalText([]byte(str))
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// configLevelSpec marshals/unmarshals the level and components of a config as a [LevelSpec].
+type configLevelSpec struct {
+	cfg *Config
+}
+
+func (s configLevelSpec) MarshalText() ([]byte, error) {
+	spec := LevelSpec{Default: s.cfg.Level, Components: s.cfg.Components}
+
+	return spec.MarshalText()
+}
+
+func (s configLevelSpec) UnmarshalText(b []byte) error {
+	var spec LevelSpec
+
+	err := spec.UnmarshalText(b)
+	if err != nil {
+		return err
+	}
+
+	s.cfg.Level = spec.Default
+	s.cfg.Components = nil
+
+	if len(spec.Components) > 0 {
+		s.cfg.Components = spec.Components
+	}
+
+	return nil
+}
+
+// configFlag is a [flag.Value] that records in its config when it was set.
+type configFlag struct {
+	name  string
+	cfg   *Config
+	value interface {
+		encoding.TextMarshaler
+		encoding.TextUnmarshaler
+	}
+}
+
+func (f *configFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+
+	b, err := f.value.MarshalText()
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+func (f *configFlag) Set(s string) error {
+	err := f.value.UnmarshalText([]byte(s))
+	if err != nil {
+		return fmt.Errorf("unmarshal text: %w", err)
+	}
+
+	if f.cfg.fromFlags == nil {
+		f.cfg.fromFlags = map[string]bool{}
+	}
+
+	f.cfg.fromFlags[f.name] = true
+
+	return nil
+}
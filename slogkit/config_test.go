@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPrecedence(t *testing.T) {
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		args        []string
+		wantLevel   slog.Level
+		wantHandler slogkit.Handler
+	}{
+		{
+			name:        "Defaults",
+			env:         nil,
+			args:        nil,
+			wantLevel:   slog.LevelInfo,
+			wantHandler: slogkit.HandlerAuto,
+		},
+		{
+			name:        "Env",
+			env:         map[string]string{"APP_LOG_LEVEL": "debug", "APP_LOG_HANDLER": "json"},
+			args:        nil,
+			wantLevel:   slog.LevelDebug,
+			wantHandler: slogkit.HandlerJSON,
+		},
+		{
+			name:        "EnvFormatAlias",
+			env:         map[string]string{"APP_LOG_FORMAT": "tint"},
+			args:        nil,
+			wantLevel:   slog.LevelInfo,
+			wantHandler: slogkit.HandlerTint,
+		},
+		{
+			name:        "EnvWrongPrefix",
+			env:         map[string]string{"LOG_LEVEL": "debug"},
+			args:        nil,
+			wantLevel:   slog.LevelInfo,
+			wantHandler: slogkit.HandlerAuto,
+		},
+		{
+			name:        "FlagsOverEnv",
+			env:         map[string]string{"APP_LOG_LEVEL": "debug", "APP_LOG_HANDLER": "json"},
+			args:        []string{"-log-level", "warn"},
+			wantLevel:   slog.LevelWarn,
+			wantHandler: slogkit.HandlerJSON,
+		},
+		{
+			name:        "Flags",
+			env:         nil,
+			args:        []string{"-log-level", "error+2", "-log-handler", "text"},
+			wantLevel:   slog.LevelError + 2,
+			wantHandler: slogkit.HandlerText,
+		},
+	}
+
+	for _, tCase := range testCases {
+		for _, envFirst := range []bool{true, false} {
+			name := tCase.name + "-FlagsFirst"
+			if envFirst {
+				name = tCase.name + "-EnvFirst"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				for k, v := range tCase.env {
+					t.Setenv(k, v)
+				}
+
+				cfg := slogkit.NewConfig()
+				fs := flag.NewFlagSet("test", flag.ContinueOnError)
+				cfg.RegisterFlags(fs)
+
+				if envFirst {
+					require.NoError(t, cfg.LoadEnv("APP_"))
+				}
+
+				require.NoError(t, fs.Parse(tCase.args))
+
+				if !envFirst {
+					require.NoError(t, cfg.LoadEnv("APP_"))
+				}
+
+				assert.Equal(t, tCase.wantLevel, cfg.Level)
+				assert.Equal(t, tCase.wantHandler, cfg.Handler)
+			})
+		}
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	t.Run("InvalidEnvHandler", func(t *testing.T) {
+		t.Setenv("LOG_HANDLER", "foobar")
+
+		err := slogkit.NewConfig().LoadEnv("")
+		require.ErrorIs(t, err, slogkit.ErrUnknownHandlerName)
+		assert.ErrorContains(t, err, "LOG_HANDLER")
+	})
+
+	t.Run("InvalidEnvLevel", func(t *testing.T) {
+		t.Setenv("LOG_LEVEL", "foobar")
+
+		err := slogkit.NewConfig().LoadEnv("")
+		assert.ErrorContains(t, err, "LOG_LEVEL")
+	})
+
+	t.Run("InvalidFlag", func(t *testing.T) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		slogkit.NewConfig().RegisterFlags(fs)
+
+		err := fs.Parse([]string{"-log-handler", "foobar"})
+		assert.ErrorContains(t, err, slogkit.ErrUnknownHandlerName.Error())
+	})
+
+	t.Run("BuildUnknownHandler", func(t *testing.T) {
+		cfg := slogkit.NewConfig()
+		cfg.Handler = -1
+
+		_, err := cfg.Build()
+		require.ErrorIs(t, err, slogkit.ErrUnknownHandlerName)
+	})
+}
+
+func TestConfigBuild(t *testing.T) {
+	var buf bytes.Buffer
+
+	cfg := slogkit.NewConfig()
+	cfg.Writer = &buf
+	cfg.Handler = slogkit.HandlerJSON
+	cfg.Level = slog.LevelWarn
+	cfg.Options = []slogkit.Option{slogkit.WithTimeKey(slog.TimeKey)}
+
+	logger, err := cfg.Build()
+	require.NoError(t, err)
+
+	logger.Info("ignored")
+	logger.Warn("message")
+	assert.Regexp(t, `^{"time":".+","level":"WARN","msg":"message"}\n$`, buf.String())
+}
+
+func TestConfigFlagDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	slogkit.NewConfig().RegisterFlags(fs)
+
+	assert.Equal(t, "INFO", fs.Lookup(slogkit.FlagLogLevel).DefValue)
+	assert.Equal(t, "auto", fs.Lookup(slogkit.FlagLogHandler).DefValue)
+}
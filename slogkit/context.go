@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// contextKey is the type of the context keys used by slogkit.
+type contextKey int
+
+const (
+	attrsContextKey contextKey = iota
+	loggerContextKey
+)
+
+// registeredKey is a context key whose value is added to records by the context handler.
+type registeredKey struct {
+	attrKey string
+	ctxKey  any
+}
+
+//nolint:gochecknoglobals // Process-wide registry of context keys.
+var (
+	registeredKeysMu sync.Mutex
+	registeredKeys   atomic.Pointer[[]registeredKey]
+)
+
+// WithAttrs returns a copy of ctx with the given attributes added to those already in ctx.
+// The arguments are interpreted as in [slog.Logger.Log]. The attributes are added to every record
+// logged with ctx by loggers created by slogkit (see [NewContextHandler]).
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	attrs := slog.Group("", args...).Value.Group()
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, attrsContextKey, append(slices.Clip(ContextAttrs(ctx)), attrs...))
+}
+
+// ContextAttrs returns the attributes added to ctx with [WithAttrs].
+// The returned slice must not be modified.
+func ContextAttrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsContextKey).([]slog.Attr)
+
+	return attrs
+}
+
+// IntoContext returns a copy of ctx that carries logger.
+func IntoContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext returns the logger carried by ctx or [slog.Default] if there is none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// RegisterContextKey registers a context key whose value, if present in the context of a record,
+// is added to the record as an attribute with key attrKey by [NewContextHandler]. It allows
+// adding values stored in contexts by other packages, for example request IDs set by middleware.
+// Registering attrKey again replaces its context key.
+func RegisterContextKey(attrKey string, ctxKey any) {
+	registeredKeysMu.Lock()
+	defer registeredKeysMu.Unlock()
+
+	var keys []registeredKey
+	if p := registeredKeys.Load(); p != nil {
+		keys = slices.DeleteFunc(slices.Clone(*p), func(k registeredKey) bool { return k.attrKey == attrKey })
+	}
+
+	keys = append(keys, registeredKey{attrKey: attrKey, ctxKey: ctxKey})
+	registeredKeys.Store(&keys)
+}
+
+// contextHandler is an slog Handler that adds context attributes to records.
+type contextHandler struct {
+	next    slog.Handler
+	base    slog.Handler                      // next without attributes and groups
+	ops     []func(slog.Handler) slog.Handler // WithAttrs and WithGroup calls applied to base
+	grouped bool                              // whether a group was opened
+	cache   *atomic.Pointer[contextCache]     // last handler replayed with context attributes
+}
+
+// contextCache is a handler replayed with the context attributes of a record. It is reused for
+// records with the same attributes added with [WithAttrs] and the same registered key values.
+type contextCache struct {
+	keys    *[]registeredKey
+	attrs   []slog.Attr
+	values  []any
+	handler slog.Handler
+}
+
+// NewContextHandler creates an slog Handler that adds to every record passed to next the
+// attributes of its context added with [WithAttrs] and the values of the context keys registered
+// with [RegisterContextKey]. Context attributes are always added at the top level, even if
+// groups were opened. Loggers created by slogkit already use this handler.
+func NewContextHandler(next slog.Handler) slog.Handler {
+	return &contextHandler{next: next, base: next, ops: nil, grouped: false, cache: nil}
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		return h.next.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+	}
+
+	keys := registeredKeys.Load()
+	attrs, values := ContextAttrs(ctx), registeredValues(ctx, keys)
+
+	if len(attrs) == 0 && !slices.ContainsFunc(values, func(v any) bool { return v != nil }) {
+		return h.next.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+	}
+
+	if !h.grouped {
+		r = r.Clone()
+		r.AddAttrs(contextAttrs(attrs, keys, values)...)
+
+		return h.next.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+	}
+
+	if c := h.cache.Load(); c != nil && c.matches(keys, attrs, values) {
+		return c.handler.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+	}
+
+	// Replay the attributes and groups of the handler after the context attributes.
+	next := h.base.WithAttrs(contextAttrs(attrs, keys, values))
+	for _, op := range h.ops {
+		next = op(next)
+	}
+
+	h.cache.Store(&contextCache{keys: keys, attrs: attrs, values: values, handler: next})
+
+	return next.Handle(ctx, r) //nolint:wrapcheck // Transparent wrapper.
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{
+		next:    h.next.WithAttrs(attrs),
+		base:    h.base,
+		ops:     append(slices.Clip(h.ops), func(n slog.Handler) slog.Handler { return n.WithAttrs(attrs) }),
+		grouped: h.grouped,
+		cache:   newContextCache(h.grouped),
+	}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &contextHandler{
+		next:    h.next.WithGroup(name),
+		base:    h.base,
+		ops:     append(slices.Clip(h.ops), func(n slog.Handler) slog.Handler { return n.WithGroup(name) }),
+		grouped: true,
+		cache:   newContextCache(true),
+	}
+}
+
+// newContextCache returns a cache for the replayed handler, which is only used with groups.
+func newContextCache(grouped bool) *atomic.Pointer[contextCache] {
+	if !grouped {
+		return nil
+	}
+
+	return new(atomic.Pointer[contextCache])
+}
+
+// matches reports whether the cached handler was replayed with the same context attributes.
+// Attributes added with [WithAttrs] are compared by identity, as they are never modified.
+func (c *contextCache) matches(keys *[]registeredKey, attrs []slog.Attr, values []any) bool {
+	return c.keys == keys &&
+		len(c.attrs) == len(attrs) && (len(attrs) == 0 || &c.attrs[0] == &attrs[0]) &&
+		slices.EqualFunc(c.values, values, sameValue)
+}
+
+// sameValue reports whether a and b are equal comparable values.
+func sameValue(a, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	return va.Type() == vb.Type() && va.Comparable() && vb.Comparable() && va.Equal(vb)
+}
+
+// registeredValues returns the values in ctx of the registered context keys.
+func registeredValues(ctx context.Context, keys *[]registeredKey) []any {
+	if keys == nil {
+		return nil
+	}
+
+	values := make([]any, len(*keys))
+	for i, k := range *keys {
+		values[i] = ctx.Value(k.ctxKey)
+	}
+
+	return values
+}
+
+// contextAttrs returns the attributes added with [WithAttrs] and those of the registered
+// context keys with values.
+func contextAttrs(attrs []slog.Attr, keys *[]registeredKey, values []any) []slog.Attr {
+	for i, v := range values {
+		if v != nil {
+			attrs = append(slices.Clip(attrs), slog.Any((*keys)[i].attrKey, v))
+		}
+	}
+
+	return attrs
+}
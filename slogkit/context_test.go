@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+)
+
+type traceIDKey struct{}
+
+func TestWithAttrs(t *testing.T) {
+	ctx := t.Context()
+	assert.Empty(t, slogkit.ContextAttrs(ctx))
+	assert.Equal(t, ctx, slogkit.WithAttrs(ctx))
+
+	ctx1 := slogkit.WithAttrs(ctx, "request_id", "r1")
+	ctx2 := slogkit.WithAttrs(ctx1, slog.String("tenant", "t1"))
+	ctx3 := slogkit.WithAttrs(ctx1, "tenant", "t2")
+
+	assert.Equal(t, []slog.Attr{slog.String("request_id", "r1")}, slogkit.ContextAttrs(ctx1))
+	assert.Equal(t, []slog.Attr{slog.String("request_id", "r1"), slog.String("tenant", "t1")},
+		slogkit.ContextAttrs(ctx2))
+	assert.Equal(t, []slog.Attr{slog.String("request_id", "r1"), slog.String("tenant", "t2")},
+		slogkit.ContextAttrs(ctx3))
+}
+
+func TestIntoContext(t *testing.T) {
+	assert.Same(t, slog.Default(), slogkit.FromContext(t.Context()))
+
+	logger := slog.New(slog.DiscardHandler)
+	assert.Same(t, logger, slogkit.FromContext(slogkit.IntoContext(t.Context(), logger)))
+}
+
+func TestContextHandler(t *testing.T) {
+	slogkit.RegisterContextKey("trace_id", traceIDKey{})
+
+	ctx := slogkit.WithAttrs(t.Context(), "request_id", "r1", "tenant", "t1")
+	ctx = context.WithValue(ctx, traceIDKey{}, "abc123")
+
+	testCases := []struct {
+		name    string
+		handler slogkit.Handler
+		want    *regexp.Regexp
+	}{
+		{
+			name:    "HandlerText",
+			handler: slogkit.HandlerText,
+			want: regexp.MustCompile(`^ts=\S+ level=INFO msg=message app=test key=val ` +
+				`request_id=r1 tenant=t1 trace_id=abc123\n` +
+				`ts=\S+ level=INFO msg=grouped request_id=r1 tenant=t1 trace_id=abc123 app=test g.key=val\n` +
+				`ts=\S+ level=INFO msg="no context" app=test\n$`),
+		},
+		{
+			name:    "HandlerJSON",
+			handler: slogkit.HandlerJSON,
+			want: regexp.MustCompile(`^{"ts":"\S+","level":"INFO","msg":"message","app":"test","key":"val",` +
+				`"request_id":"r1","tenant":"t1","trace_id":"abc123"}\n` +
+				`{"ts":"\S+","level":"INFO","msg":"grouped","request_id":"r1","tenant":"t1","trace_id":"abc123",` +
+				`"app":"test","g":{"key":"val"}}\n` +
+				`{"ts":"\S+","level":"INFO","msg":"no context","app":"test"}\n$`),
+		},
+		{
+			name:    "HandlerTint",
+			handler: slogkit.HandlerTint,
+			want:    regexp.MustCompile(`message .*request_id=.*r1.*tenant=.*t1.*trace_id=.*abc123`),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := slogkit.NewLogger(&buf, tCase.handler, slog.LevelInfo).With("app", "test")
+			logger.InfoContext(ctx, "message", "key", "val")
+			logger.WithGroup("g").InfoContext(ctx, "grouped", "key", "val")
+			logger.Info("no context")
+			assert.Regexp(t, tCase.want, buf.String())
+		})
+	}
+}
+
+func TestContextHandlerComponents(t *testing.T) {
+	var buf bytes.Buffer
+
+	levels := slogkit.NewComponentLevels(slogkit.LevelSpec{
+		Default:    slog.LevelInfo,
+		Components: map[string]slog.Level{"db": slog.LevelDebug},
+	})
+	logger := slogkit.NewLogger(&buf, slogkit.HandlerText, levels)
+
+	logger.DebugContext(slogkit.WithAttrs(t.Context(), slogkit.ComponentKey, "db"), "query")
+	logger.DebugContext(slogkit.WithAttrs(t.Context(), slogkit.ComponentKey, "http"), "request")
+	assert.Contains(t, buf.String(), "msg=query component=db\n")
+	assert.NotContains(t, buf.String(), "request")
+}
+
+func TestContextHandlerReplayCache(t *testing.T) {
+	type userKey struct{}
+
+	slogkit.RegisterContextKey("user", userKey{})
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLogger(&buf, slogkit.HandlerText, slog.LevelInfo).WithGroup("g").With("key", "val")
+
+	ctx1 := slogkit.WithAttrs(t.Context(), "request_id", "r1")
+	ctx2 := slogkit.WithAttrs(t.Context(), "request_id", "r2")
+	ctx3 := context.WithValue(ctx1, userKey{}, "alice")
+	ctx4 := context.WithValue(ctx1, userKey{}, "bob")
+	ctx5 := context.WithValue(ctx1, userKey{}, []string{"uncomparable"})
+
+	for _, ctx := range []context.Context{ctx1, ctx1, ctx2, ctx1, ctx3, ctx4, ctx4, ctx5, ctx5, t.Context()} {
+		logger.InfoContext(ctx, "message")
+	}
+
+	assert.Regexp(t, `^`+
+		`ts=\S+ level=INFO msg=message request_id=r1 g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r2 g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 user=alice g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 user=bob g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 user=bob g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 user=\[uncomparable\] g.key=val\n`+
+		`ts=\S+ level=INFO msg=message request_id=r1 user=\[uncomparable\] g.key=val\n`+
+		`ts=\S+ level=INFO msg=message g.key=val\n$`, buf.String())
+}
+
+func BenchmarkContextHandler(b *testing.B) {
+	ctx := slogkit.WithAttrs(b.Context(), "request_id", "r1", "tenant", "t1")
+
+	loggers := []struct {
+		name   string
+		logger *slog.Logger
+	}{
+		{
+			name:   "TextHandler",
+			logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		},
+		{
+			name:   "NewLogger",
+			logger: slogkit.NewLogger(io.Discard, slogkit.HandlerText, slog.LevelInfo),
+		},
+	}
+
+	for _, l := range loggers {
+		logger := l.logger.With("app", "test").WithGroup("g").With("a", 1).With("b", 2).With("c", 3)
+
+		b.Run(l.name+"/NoContext", func(b *testing.B) {
+			for b.Loop() {
+				logger.InfoContext(b.Context(), "message", "key", "val")
+			}
+		})
+
+		b.Run(l.name+"/Context", func(b *testing.B) {
+			for b.Loop() {
+				logger.InfoContext(ctx, "message", "key", "val")
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Attribute keys of the group created by [Err].
+const (
+	errMessageKey = "msg"
+	errTypeKey    = "type"
+	errCauseKey   = "cause"
+	errErrorsKey  = "errors"
+	errStackKey   = "stack"
+)
+
+const (
+	// maxErrDepth is the maximum depth of nested causes and joined errors expanded by [Err].
+	maxErrDepth = 16
+	// maxStackDepth is the maximum number of frames captured by [Errorf] and [WithStack].
+	maxStackDepth = 32
+)
+
+// Err returns an attribute with the [ErrorKey] key that expands err into a group with the
+// message ("msg") and the dynamic type ("type") of the error. The error returned by its Unwrap
+// method is expanded recursively into a "cause" group and the errors returned by Unwrap []error,
+// for example by [errors.Join], into an "errors" group keyed by their index. Errors created
+// with [Errorf] or [WithStack] also include the captured stack trace ("stack") as a list of
+// "function (file:line)" frames. The group is computed only if the record is logged.
+// A nil err returns an empty attribute, which handlers ignore.
+//
+// For example, the text handler renders:
+//
+//	err.msg="read config: open app.yaml: no such file or directory" err.type=*fmt.wrapError
+//	err.cause.msg="open app.yaml: no such file or directory" err.cause.type=*fs.PathError
+//	err.cause.cause.msg="no such file or directory" err.cause.cause.type=syscall.Errno
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{} //nolint:exhaustruct_v5
+	}
+
+	return slog.Any(ErrorKey, errValuer{err: err})
+}
+
+// errValuer is a [slog.LogValuer] that expands an error into a group.
+type errValuer struct {
+	err error
+}
+
+func (v errValuer) LogValue() slog.Value {
+	return slog.GroupValue(errAttrs(v.err, 0)...)
+}
+
+// errAttrs returns the attributes of the group of err at the given nesting depth.
+func errAttrs(err error, depth int) []slog.Attr {
+	// Stack errors are transparent, their stack is reported with the error they wrap.
+	var pcs []uintptr
+	if se, ok := err.(*stackError); ok { //nolint:errorlint // Only the error itself is unwrapped.
+		err, pcs = se.err, se.pcs
+	}
+
+	attrs := []slog.Attr{
+		slog.String(errMessageKey, err.Error()),
+		slog.String(errTypeKey, fmt.Sprintf("%T", err)),
+	}
+
+	if depth < maxErrDepth {
+		switch u := err.(type) { //nolint:errorlint // Only the error itself is unwrapped.
+		case interface{ Unwrap() error }:
+			if cause := u.Unwrap(); cause != nil {
+				attrs = append(attrs, slog.Attr{Key: errCauseKey, Value: slog.GroupValue(errAttrs(cause, depth+1)...)})
+			}
+		case interface{ Unwrap() []error }:
+			var children []slog.Attr
+
+			for i, child := range u.Unwrap() {
+				if child != nil {
+					children = append(children,
+						slog.Attr{Key: strconv.Itoa(i), Value: slog.GroupValue(errAttrs(child, depth+1)...)})
+				}
+			}
+
+			attrs = append(attrs, slog.Attr{Key: errErrorsKey, Value: slog.GroupValue(children...)})
+		}
+	}
+
+	if len(pcs) != 0 {
+		attrs = append(attrs, slog.Any(errStackKey, stackFrames(pcs)))
+	}
+
+	return attrs
+}
+
+// stackError is an error with the stack trace captured when it was created.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// Errorf is like [fmt.Errorf] but also captures the stack trace of the caller,
+// which is included by [Err] when the error is logged.
+func Errorf(format string, args ...any) error {
+	return withStack(fmt.Errorf(format, args...))
+}
+
+// WithStack returns an error wrapping err with the stack trace of the caller, which is included
+// by [Err] when the error is logged. The returned error has the same message as err.
+// If err already carries a stack trace captured by slogkit, it is returned unchanged
+// to keep the stack closest to the origin of the error. A nil err returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return withStack(err)
+}
+
+func withStack(err error) error {
+	var se *stackError
+	if errors.As(err, &se) {
+		return err
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs) //nolint:mnd // Skip Callers, withStack and Errorf/WithStack.
+
+	return &stackError{err: err, pcs: pcs[:n]}
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// errStack returns the stack trace captured in the chain of err, formatted like the stack of a
+// goroutine in a panic, or an empty string if there is none.
+func errStack(err error) string {
+	var se *stackError
+	if !errors.As(err, &se) || len(se.pcs) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	frames := runtime.CallersFrames(se.pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+
+		if !more {
+			return b.String()
+		}
+	}
+}
+
+// stackFrames returns the frames of a stack trace formatted as "function (file:line)".
+func stackFrames(pcs []uintptr) []string {
+	out := make([]string, 0, len(pcs))
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		out = append(out, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+
+		if !more {
+			return out
+		}
+	}
+}
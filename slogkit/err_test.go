@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErr(t *testing.T) {
+	_, err := os.Open("testdata/missing.yaml")
+	err = fmt.Errorf("read config: %w", err)
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLogger(&buf, slogkit.HandlerText, slog.LevelInfo)
+		logger.Error("failed", slogkit.Err(err))
+
+		assert.Regexp(t, `^ts=\S+ level=ERROR msg=failed `+
+			`err.msg="read config: open testdata/missing.yaml: no such file or directory" err.type=\*fmt.wrapError `+
+			`err.cause.msg="open testdata/missing.yaml: no such file or directory" err.cause.type=\*fs.PathError `+
+			`err.cause.cause.msg="no such file or directory" err.cause.cause.type=syscall.Errno\n$`, buf.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLogger(&buf, slogkit.HandlerJSON, slog.LevelInfo)
+		logger.Error("failed", slogkit.Err(errors.Join(err, errors.New("second"))))
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, map[string]any{
+			"msg":  "read config: open testdata/missing.yaml: no such file or directory\nsecond",
+			"type": "*errors.joinError",
+			"errors": map[string]any{
+				"0": map[string]any{
+					"msg":  "read config: open testdata/missing.yaml: no such file or directory",
+					"type": "*fmt.wrapError",
+					"cause": map[string]any{
+						"msg":  "open testdata/missing.yaml: no such file or directory",
+						"type": "*fs.PathError",
+						"cause": map[string]any{
+							"msg":  "no such file or directory",
+							"type": "syscall.Errno",
+						},
+					},
+				},
+				"1": map[string]any{
+					"msg":  "second",
+					"type": "*errors.errorString",
+				},
+			},
+		}, entry["err"])
+	})
+
+	t.Run("Tint", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerTint, slog.LevelInfo, slogkit.WithNoColor(true))
+		logger.Error("failed", slogkit.Err(errors.New("boom")))
+
+		assert.Regexp(t, `ERR failed err.msg=boom err.type=\*errors.errorString\n$`, buf.String())
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		logger := slogkit.NewLogger(&buf, slogkit.HandlerText, slog.LevelInfo)
+		logger.Info("done", slogkit.Err(nil))
+
+		assert.Regexp(t, `^ts=\S+ level=INFO msg=done\n$`, buf.String())
+	})
+}
+
+func TestErrorf(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	err := slogkit.Errorf("read %s: %w", "config", fs.ErrNotExist)
+
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	assert.EqualError(t, err, "read config: file does not exist")
+	assert.Same(t, err, slogkit.WithStack(err))
+	assert.Same(t, err, errors.Unwrap(slogkit.WithStack(fmt.Errorf("wrapped: %w", err))))
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLogger(&buf, slogkit.HandlerJSON, slog.LevelInfo)
+	logger.Error("failed", slogkit.Err(err))
+
+	var entry struct {
+		Err struct {
+			Msg   string   `json:"msg"`
+			Type  string   `json:"type"`
+			Stack []string `json:"stack"`
+		} `json:"err"`
+	}
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "read config: file does not exist", entry.Err.Msg)
+	assert.Equal(t, "*fmt.wrapError", entry.Err.Type)
+	require.NotEmpty(t, entry.Err.Stack)
+	assert.Equal(t, "github.com/hhromic/go-toolkit/slogkit_test.TestErrorf ("+file+":"+strconv.Itoa(line+1)+")",
+		entry.Err.Stack[0])
+}
+
+func TestWithStack(t *testing.T) {
+	assert.NoError(t, slogkit.WithStack(nil))
+
+	_, file, line, _ := runtime.Caller(0)
+	err := slogkit.WithStack(fs.ErrClosed)
+
+	require.ErrorIs(t, err, fs.ErrClosed)
+	assert.EqualError(t, err, fs.ErrClosed.Error())
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLogger(&buf, slogkit.HandlerText, slog.LevelInfo)
+	logger.Error("failed", slogkit.Err(fmt.Errorf("close: %w", err)))
+
+	assert.Regexp(t, `err.msg="close: file already closed" err.type=\*fmt.wrapError `+
+		`err.cause.msg="file already closed" err.cause.type=\*errors.errorString `+
+		`err.cause.stack="\[github.com/hhromic/go-toolkit/slogkit_test.TestWithStack \(`+file+`:`+strconv.Itoa(line+1)+`\) `,
+		buf.String())
+}
@@ -9,4 +9,14 @@ import "errors"
 var (
 	// ErrUnknownHandlerName is returned when an unknown slogkit handler name is used.
 	ErrUnknownHandlerName = errors.New("unknown handler name")
+	// ErrUnknownLevelName is returned when an unknown level name is used.
+	ErrUnknownLevelName = errors.New("unknown level name")
+	// ErrInvalidLevelSpec is returned when a level spec string is not valid.
+	ErrInvalidLevelSpec = errors.New("invalid level spec")
+	// ErrHandlerClosed is returned when a record is passed to a closed handler.
+	ErrHandlerClosed = errors.New("handler closed")
+	// ErrHandlerPanicked is returned when a handler panics while logging a recovered panic.
+	ErrHandlerPanicked = errors.New("handler panicked")
+	// ErrStdLogLoop is returned when redirecting the standard logger to a logger that writes to it.
+	ErrStdLogLoop = errors.New("logger writes to the standard logger")
 )
@@ -4,9 +4,15 @@
 package slogkit_test
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/hhromic/go-toolkit/slogkit"
 )
@@ -59,3 +65,282 @@ func ExampleNewLogger_setDefault() {
 	version := "1.2.3"
 	slog.Info("application started", "version", version)
 }
+
+func ExampleNewLoggerWithOptions() {
+	logger := slogkit.NewLoggerWithOptions(
+		os.Stdout, slogkit.HandlerJSON, slog.LevelDebug,
+		slogkit.WithSource(true),
+		slogkit.WithTimeKey(slog.TimeKey),
+		slogkit.WithTimeLayout(time.RFC3339Nano),
+		slogkit.WithTimeUTC(),
+	)
+
+	version := "1.2.3"
+	logger.Info("application started", "version", version)
+}
+
+func ExampleConfig() {
+	cfg := slogkit.NewConfig()
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	err := cfg.LoadEnv("MYAPP_")
+	if err != nil {
+		panic(err)
+	}
+
+	logger, err := cfg.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	logger.Info("application started")
+}
+
+func ExampleLevelHandler() {
+	var level slog.LevelVar
+
+	logger := slogkit.NewLogger(os.Stdout, slogkit.HandlerText, &level)
+
+	// GET /log/level returns the current level.
+	// PUT /log/level?ttl=10m with body "debug" enables debug logging for ten minutes.
+	http.Handle("/log/level", slogkit.NewLevelHandler(&level, logger))
+}
+
+func ExampleFatal() {
+	logger := slogkit.NewLogger(os.Stdout, slogkit.HandlerText, slogkit.LevelTrace)
+
+	logger.Log(context.Background(), slogkit.LevelTrace, "connecting", "attempt", 1)
+	logger.Log(context.Background(), slogkit.LevelNotice, "configuration reloaded")
+
+	err := errors.New("listen: address already in use")
+	slogkit.Fatal(logger, "cannot start server", "error", err)
+}
+
+func ExampleNewMultiLogger() {
+	file, err := os.CreateTemp("", "debug-*.log")
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	logger := slogkit.NewMultiLogger(
+		// JSON at INFO+ to stdout for the log shipper.
+		slogkit.Sink{Writer: os.Stdout, Handler: slogkit.HandlerJSON, Leveler: slog.LevelInfo, Options: nil},
+		// Colorized at DEBUG+ to a local file.
+		slogkit.Sink{Writer: file, Handler: slogkit.HandlerTint, Leveler: slog.LevelDebug, Options: nil},
+	)
+
+	logger.Debug("cache warmed up", "entries", 1024)
+	logger.Info("application started")
+}
+
+func ExampleRotatingFile() {
+	rf, err := slogkit.NewRotatingFile("/var/log/myapp/app.log", slogkit.RotatingFileConfig{
+		MaxSize:        100 << 20, // 100 MiB
+		Interval:       24 * time.Hour,
+		MaxBackups:     7,
+		MaxAge:         30 * 24 * time.Hour,
+		Compress:       true,
+		ReopenOnSIGHUP: true,
+		Now:            nil,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer rf.Close()
+
+	logger := slogkit.NewLogger(rf, slogkit.HandlerJSON, slog.LevelInfo)
+	logger.Info("application started")
+}
+
+func ExampleNewAsyncHandler() {
+	async := slogkit.NewAsyncHandler(
+		slogkit.NewLogger(os.Stdout, slogkit.HandlerJSON, slog.LevelInfo).Handler(),
+		slogkit.AsyncConfig{
+			QueueSize:       4096,
+			Overflow:        slogkit.OverflowDropBelowLevel,
+			DropLevel:       slog.LevelWarn,
+			SummaryInterval: time.Minute,
+		},
+	)
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_ = async.Close(ctx)
+	}()
+
+	logger := slog.New(async)
+	logger.Info("application started")
+}
+
+func ExampleNewSamplingHandler() {
+	sampler := slogkit.NewSamplingHandler(
+		slogkit.NewLogger(os.Stdout, slogkit.HandlerJSON, slog.LevelInfo).Handler(),
+		slogkit.SamplingConfig{
+			Interval:   time.Second,
+			First:      10,
+			Thereafter: 100,
+			Exempt:     nil, // ERROR and above are never sampled.
+			Collapse:   true,
+			Now:        nil,
+		},
+	)
+
+	logger := slog.New(sampler)
+	for range 1000 {
+		logger.Warn("dependency unavailable", "dep", "db")
+	}
+
+	_ = sampler.Flush(context.Background())
+}
+
+func ExampleWithRedaction() {
+	logger := slogkit.NewLoggerWithOptions(
+		os.Stdout, slogkit.HandlerJSON, slog.LevelInfo,
+		slogkit.WithRedaction(slogkit.RedactConfig{KeyPattern: nil, ValueRedactors: nil}),
+	)
+
+	logger.Info("user logged in",
+		"email", "john.doe@example.com", // Partially masked.
+		"password", "hunter2", // Masked by key.
+		"api", slogkit.Secret("s3cr3t"), // Always masked.
+	)
+}
+
+func ExampleWithAttrs() {
+	logger := slogkit.NewLogger(os.Stdout, slogkit.HandlerJSON, slog.LevelInfo)
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ctx := slogkit.WithAttrs(r.Context(), "request_id", r.Header.Get("X-Request-Id"))
+		ctx = slogkit.IntoContext(ctx, logger)
+
+		// Anywhere down the call chain, the request ID is added to the records.
+		slogkit.FromContext(ctx).InfoContext(ctx, "handling request", "path", r.URL.Path)
+	})
+}
+
+func ExampleNewSyslogWriter() {
+	w, err := slogkit.NewSyslogWriter("", "") // Local syslog daemon at /dev/log.
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerSyslog, slog.LevelInfo,
+		slogkit.WithSyslogFacility(slogkit.SyslogLocal0),
+		slogkit.WithSyslogAppName("myapp"),
+	)
+	logger.Info("application started", slog.Group("http", "port", 8080))
+}
+
+func ExampleNewJournalWriter() {
+	w, err := slogkit.NewJournalWriter("") // Local systemd-journald.
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerJournal, slog.LevelInfo,
+		slogkit.WithSyslogAppName("myapp"),
+		slogkit.WithSource(true),
+	)
+	logger.Info("application started", slog.Group("http", "port", 8080)) // Adds HTTP_PORT=8080.
+}
+
+func ExampleWithAutoPolicy() {
+	// Use JSON instead of text when not writing to a terminal, for example in containers.
+	logger := slogkit.NewLoggerWithOptions(os.Stderr, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoJSON(true),
+	)
+	logger.Info("application started")
+
+	// Never use colors, even on terminals.
+	logger = slogkit.NewLoggerWithOptions(os.Stderr, slogkit.HandlerAuto, slog.LevelInfo,
+		slogkit.WithAutoPolicy(func(env slogkit.AutoEnv) slogkit.Handler {
+			if h := slogkit.DefaultAutoPolicy(env); h != slogkit.HandlerTint {
+				return h
+			}
+
+			return slogkit.HandlerText
+		}),
+	)
+	logger.Info("application started")
+}
+
+func ExampleWithTintTheme() {
+	logger := slogkit.NewLoggerWithOptions(os.Stderr, slogkit.HandlerTint, slog.LevelInfo,
+		slogkit.WithTimeLayout(time.TimeOnly),
+		slogkit.WithTintTheme(slogkit.TintTheme{
+			Levels: map[slog.Level]uint8{slog.LevelInfo: 12, slogkit.LevelNotice: 13},
+			Keys:   map[string]uint8{"err": 9, "http.status": 11},
+		}),
+	)
+	logger.Error("request failed", "err", errors.New("connection reset"), slog.Group("http", "status", 502))
+}
+
+func ExampleRedirectStdLog() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerText, slog.LevelInfo)
+
+	restore, err := slogkit.RedirectStdLog(logger, slog.LevelInfo)
+	if err != nil {
+		logger.Error("error redirecting the standard logger", "err", err)
+
+		return
+	}
+	defer restore()
+
+	log.Print("logged by a third-party library")
+}
+
+func ExampleNewHTTPErrorLog() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerJSON, slog.LevelInfo)
+
+	srv := &http.Server{ //nolint:exhaustruct_v5
+		Addr:              ":8443",
+		ReadHeaderTimeout: 5 * time.Second,
+		ErrorLog:          slogkit.NewHTTPErrorLog(logger, slog.LevelWarn),
+	}
+	_ = srv.ListenAndServeTLS("cert.pem", "key.pem")
+}
+
+func ExampleNewLogr() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerText, slogkit.LevelTrace)
+
+	lr := slogkit.NewLogr(logger).WithName("controller")
+	lr.Info("reconciling", "namespace", "default")
+	lr.V(4).Info("cache hit") // logged at DEBUG
+	lr.Error(errors.New("not found"), "reconcile failed")
+}
+
+func ExampleErr() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerJSON, slog.LevelInfo)
+
+	_, err := os.Open("app.yaml")
+	if err != nil {
+		err = slogkit.Errorf("read config: %w", err)
+		logger.Error("startup failed", slogkit.Err(err))
+	}
+}
+
+func ExampleRecover() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerJSON, slog.LevelInfo)
+
+	// Log a panic in main as a single FATAL record before exiting.
+	defer slogkit.Recover(logger, slogkit.WithPanicPolicy(slogkit.PanicExit))
+
+	var cfg map[string]string
+	cfg["env"] = "production" // panics
+}
+
+func ExampleGo() {
+	logger := slogkit.NewLogger(os.Stderr, slogkit.HandlerJSON, slog.LevelInfo)
+	ctx := slogkit.WithAttrs(context.Background(), "worker", "indexer")
+
+	// Panics of the worker are logged with the worker attribute instead of crashing the process.
+	slogkit.Go(ctx, logger, func(ctx context.Context) {
+		logger.InfoContext(ctx, "indexing")
+	})
+}
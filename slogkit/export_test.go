@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+// SetJournalSocket sets the journald socket address used by [HandlerAuto] and
+// [NewJournalWriter] and returns a function that restores it. The shared writer of
+// [HandlerAuto] is closed and reconnected on next use in both cases.
+func SetJournalSocket(addr string) func() {
+	prev := journalSocket
+	journalSocket = addr
+
+	resetJournalWriter()
+
+	return func() {
+		journalSocket = prev
+
+		resetJournalWriter()
+	}
+}
+
+// SharedJournalWriter returns the shared writer of [HandlerAuto], or nil if not connected.
+func SharedJournalWriter() *JournalWriter {
+	autoJournalMu.Lock()
+	defer autoJournalMu.Unlock()
+
+	return autoJournalWriter
+}
+
+func resetJournalWriter() {
+	autoJournalMu.Lock()
+	defer autoJournalMu.Unlock()
+
+	if autoJournalWriter != nil {
+		_ = autoJournalWriter.Close()
+		autoJournalWriter = nil
+	}
+}
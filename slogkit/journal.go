@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultJournalSocket is the address of the native protocol socket of systemd-journald.
+const DefaultJournalSocket = "/run/systemd/journal/socket"
+
+const journalMaxNameLen = 64
+
+// journalAttrPrefix prefixes attribute names that collide with the fields written by the handler.
+const journalAttrPrefix = "ATTR_"
+
+//nolint:gochecknoglobals // Overridden in tests.
+var journalSocket = DefaultJournalSocket
+
+//nolint:gochecknoglobals // Process-wide connection shared by the loggers of HandlerAuto.
+var (
+	autoJournalMu     sync.Mutex
+	autoJournalWriter *JournalWriter
+)
+
+// sharedJournalWriter returns the [JournalWriter] shared by all the loggers for which
+// [HandlerAuto] selects [HandlerJournal], connecting it on first use. It is never closed.
+func sharedJournalWriter() (*JournalWriter, error) {
+	autoJournalMu.Lock()
+	defer autoJournalMu.Unlock()
+
+	if autoJournalWriter == nil {
+		w, err := NewJournalWriter("")
+		if err != nil {
+			return nil, err
+		}
+
+		autoJournalWriter = w
+	}
+
+	return autoJournalWriter, nil
+}
+
+// journalHandler is an slog Handler that formats records as systemd journal entries.
+type journalHandler struct {
+	writer  io.Writer
+	mu      *sync.Mutex
+	leveler slog.Leveler
+	o       *options
+	rep     ReplaceAttrFunc
+	ident   string
+	attrs   []groupedAttr
+	groups  []string
+}
+
+// newJournalHandler creates the slog Handler of [HandlerJournal].
+func newJournalHandler(writer io.Writer, leveler slog.Leveler, o *options) slog.Handler {
+	if leveler == nil {
+		leveler = slog.LevelInfo
+	}
+
+	ident := o.syslogAppName
+	if ident == "" {
+		ident = filepath.Base(os.Args[0])
+	}
+
+	return &journalHandler{
+		writer:  writer,
+		mu:      &sync.Mutex{},
+		leveler: leveler,
+		o:       o,
+		rep:     o.replaceAttr(),
+		ident:   ident,
+		attrs:   nil,
+		groups:  nil,
+	}
+}
+
+func (h *journalHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.leveler.Level()
+}
+
+func (h *journalHandler) Handle(_ context.Context, r slog.Record) error {
+	var b bytes.Buffer
+
+	msg := r.Message
+	if a := h.rep(nil, slog.String(slog.MessageKey, msg)); a.Key != "" {
+		msg = a.Value.String()
+	}
+
+	journalAppend(&b, "MESSAGE", msg)
+	journalAppend(&b, "PRIORITY", strconv.Itoa(syslogSeverity(r.Level)))
+	journalAppend(&b, "SYSLOG_IDENTIFIER", h.ident)
+
+	add := func(groups []string, a slog.Attr) {
+		if name := journalName(append(slices.Clip(groups), a.Key)); name != "" {
+			journalAppend(&b, name, a.Value.String())
+		}
+	}
+
+	if h.o.addSource && r.PC != 0 {
+		if a := h.rep(nil, slog.Any(slog.SourceKey, r.Source())); a.Key != "" {
+			if src, ok := a.Value.Any().(*slog.Source); ok {
+				journalAppend(&b, "CODE_FILE", src.File)
+				journalAppend(&b, "CODE_LINE", strconv.Itoa(src.Line))
+				journalAppend(&b, "CODE_FUNC", src.Function)
+			} else {
+				add(nil, a)
+			}
+		}
+	}
+
+	for _, ga := range h.attrs {
+		flattenAttr(h.rep, ga.groups, ga.attr, add)
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		flattenAttr(h.rep, h.groups, a, add)
+
+		return true
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := h.writer.Write(b.Bytes())
+
+	return err //nolint:wrapcheck // Transparent writer.
+}
+
+func (h *journalHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, groupedAttr{groups: h.groups, attr: a})
+	}
+
+	return &h2
+}
+
+func (h *journalHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
+}
+
+// journalAppend appends a field to b in the native journal protocol format. Values with newlines
+// are written as a little-endian 64-bit length followed by the value.
+func journalAppend(b *bytes.Buffer, name, value string) {
+	b.WriteString(name)
+
+	if !strings.Contains(value, "\n") {
+		b.WriteString("=" + value + "\n")
+
+		return
+	}
+
+	b.WriteByte('\n')
+	_ = binary.Write(b, binary.LittleEndian, uint64(len(value)))
+	b.WriteString(value + "\n")
+}
+
+// journalName returns a journal field name for the keys: joined with underscores, uppercase,
+// only letters, digits and underscores, not starting with an underscore or digit and at most
+// 64 long. Names of the fields written by the handler itself, such as MESSAGE, are prefixed with
+// "ATTR_" to not override them. It returns an empty string if no valid name remains.
+func journalName(keys []string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, strings.Join(keys, "_"))
+
+	name = strings.TrimLeft(name, "_0123456789")
+	name = name[:min(len(name), journalMaxNameLen)]
+
+	switch name {
+	case "MESSAGE", "PRIORITY", "SYSLOG_IDENTIFIER", "CODE_FILE", "CODE_LINE", "CODE_FUNC":
+		return journalAttrPrefix + name
+	}
+
+	return name
+}
+
+// JournalWriter is an [io.Writer] that sends each write as an entry to systemd-journald using
+// the native protocol. Use it with [HandlerJournal], which formats each record in a single write.
+// Entries too large for a datagram are passed to journald in a sealed memory file (Linux only).
+// It is safe for concurrent use.
+type JournalWriter struct {
+	conn *net.UnixConn
+}
+
+// NewJournalWriter creates a [JournalWriter] connected to the journald socket at addr, or at
+// [DefaultJournalSocket] if addr is empty.
+func NewJournalWriter(addr string) (*JournalWriter, error) {
+	if addr == "" {
+		addr = journalSocket
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: addr, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("journal dial: %w", err)
+	}
+
+	return &JournalWriter{conn: conn}, nil
+}
+
+// Write sends p as a single journal entry.
+func (w *JournalWriter) Write(p []byte) (int, error) {
+	if err := journalSend(w.conn, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to journald.
+func (w *JournalWriter) Close() error {
+	return w.conn.Close() //nolint:wrapcheck // Transparent closer.
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// journalSend sends an entry to journald, using a sealed memory file if it is too large.
+func journalSend(conn *net.UnixConn, p []byte) error {
+	_, err := conn.Write(p)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, unix.EMSGSIZE) && !errors.Is(err, unix.ENOBUFS) {
+		return fmt.Errorf("journal write: %w", err)
+	}
+
+	fd, err := unix.MemfdCreate("journal-entry", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("journal memfd: %w", err)
+	}
+
+	f := os.NewFile(uintptr(fd), "journal-entry")
+	defer f.Close()
+
+	if _, err := f.Write(p); err != nil {
+		return fmt.Errorf("journal memfd: %w", err)
+	}
+
+	seals := unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE | unix.F_SEAL_SEAL
+	if _, err := unix.FcntlInt(uintptr(fd), unix.F_ADD_SEALS, seals); err != nil {
+		return fmt.Errorf("journal memfd: %w", err)
+	}
+
+	// The memory file is passed in a message without data, which the net package does not allow
+	// on connected datagram sockets.
+	rc, err := conn.SyscallConn()
+	if err != nil {
+		return fmt.Errorf("journal write: %w", err)
+	}
+
+	var serr error
+	if err := rc.Write(func(s uintptr) bool {
+		serr = unix.Sendmsg(int(s), nil, unix.UnixRights(fd), nil, 0) //nolint:gosec // File descriptors fit in int.
+
+		return !errors.Is(serr, unix.EAGAIN)
+	}); err != nil {
+		return fmt.Errorf("journal write: %w", err)
+	}
+
+	if serr != nil {
+		return fmt.Errorf("journal write: %w", serr)
+	}
+
+	return nil
+}
+
+// isJournalStream reports whether f is the stream connected to journald by systemd, as
+// announced by the JOURNAL_STREAM environment variable.
+func isJournalStream(f *os.File) bool {
+	dev, ino, ok := strings.Cut(os.Getenv("JOURNAL_STREAM"), ":")
+	if !ok {
+		return false
+	}
+
+	var st unix.Stat_t
+	if err := unix.Fstat(int(f.Fd()), &st); err != nil { //nolint:gosec // File descriptors fit in int.
+		return false
+	}
+
+	return strconv.FormatUint(uint64(st.Dev), 10) == dev && //nolint:unconvert // Not uint64 everywhere.
+		strconv.FormatUint(uint64(st.Ino), 10) == ino //nolint:unconvert // Not uint64 everywhere.
+}
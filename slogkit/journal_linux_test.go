@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalWriterLargeEntry(t *testing.T) {
+	addr, conn := listenJournal(t)
+
+	w, err := slogkit.NewJournalWriter(addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	large := strings.Repeat("x", 4<<20)
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerJournal, slog.LevelInfo, slogkit.WithSyslogAppName("app"))
+	logger.Info("message", "large", large)
+
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(make([]byte, 1), oob)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	require.NoError(t, err)
+	require.Len(t, fds, 1)
+
+	f := os.NewFile(uintptr(fds[0]), "memfd")
+	t.Cleanup(func() { _ = f.Close() })
+
+	b, err := io.ReadAll(io.NewSectionReader(f, 0, 8<<20))
+	require.NoError(t, err)
+	assert.True(t, string(b) == "MESSAGE=message\nPRIORITY=6\nSYSLOG_IDENTIFIER=app\nLARGE="+large+"\n")
+}
+
+func TestHandlerAutoJournal(t *testing.T) {
+	clearAutoEnv(t)
+
+	addr, conn := listenJournal(t)
+	t.Cleanup(slogkit.SetJournalSocket(addr))
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "stream"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	var st syscall.Stat_t
+	require.NoError(t, syscall.Fstat(int(f.Fd()), &st)) //nolint:gosec // Test file descriptor.
+
+	t.Setenv("JOURNAL_STREAM", strconv.FormatUint(uint64(st.Dev), 10)+":"+ //nolint:unconvert // Not uint64 everywhere.
+		strconv.FormatUint(uint64(st.Ino), 10)) //nolint:unconvert // Not uint64 everywhere.
+
+	logger := slogkit.NewLoggerWithOptions(f, slogkit.HandlerAuto, slog.LevelInfo, slogkit.WithSyslogAppName("app"))
+	logger.Info("message", "key", "val")
+
+	b := make([]byte, 1024)
+	n, err := conn.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "MESSAGE=message\nPRIORITY=6\nSYSLOG_IDENTIFIER=app\nKEY=val\n", string(b[:n]))
+
+	// All the loggers share a single connection to journald.
+	jw := slogkit.SharedJournalWriter()
+	require.NotNil(t, jw)
+
+	for range 3 {
+		slogkit.NewLogger(f, slogkit.HandlerAuto, slog.LevelInfo).Info("message")
+
+		n, err = conn.Read(b)
+		require.NoError(t, err)
+		assert.Equal(t, "MESSAGE=message\nPRIORITY=6\nSYSLOG_IDENTIFIER=slogkit.test\n", string(b[:n]))
+	}
+
+	assert.Same(t, jw, slogkit.SharedJournalWriter())
+
+	// Any other stream uses the usual handlers.
+	t.Setenv("JOURNAL_STREAM", "1:1")
+
+	logger = slogkit.NewLogger(f, slogkit.HandlerAuto, slog.LevelInfo)
+	logger.Info("message")
+
+	out, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Regexp(t, `^ts=\S+ level=INFO msg=message\n$`, string(out))
+}
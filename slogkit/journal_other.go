@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package slogkit
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// journalSend sends an entry to journald.
+func journalSend(conn *net.UnixConn, p []byte) error {
+	if _, err := conn.Write(p); err != nil {
+		return fmt.Errorf("journal write: %w", err)
+	}
+
+	return nil
+}
+
+// isJournalStream reports whether f is the stream connected to journald, which is never
+// the case outside Linux.
+func isJournalStream(_ *os.File) bool {
+	return false
+}
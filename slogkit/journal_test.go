@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// journalFields parses a native journal protocol entry into its fields in order.
+func journalFields(t *testing.T, b []byte) [][2]string {
+	t.Helper()
+
+	var fields [][2]string
+
+	for len(b) > 0 {
+		i := bytes.IndexAny(b, "=\n")
+		require.GreaterOrEqual(t, i, 0)
+
+		name := string(b[:i])
+
+		if b[i] == '=' {
+			j := bytes.IndexByte(b, '\n')
+			fields = append(fields, [2]string{name, string(b[i+1 : j])})
+			b = b[j+1:]
+
+			continue
+		}
+
+		n := int(binary.LittleEndian.Uint64(b[i+1 : i+9])) //nolint:gosec // Test data.
+		fields = append(fields, [2]string{name, string(b[i+9 : i+9+n])})
+		b = b[i+9+n+1:]
+	}
+
+	return fields
+}
+
+// listenJournal starts a fake journald socket and returns its address and connection.
+func listenJournal(t *testing.T) (string, *net.UnixConn) {
+	t.Helper()
+
+	addr := filepath.Join(t.TempDir(), "journal.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return addr, conn
+}
+
+func TestHandlerJournal(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerJournal, slogkit.LevelTrace,
+		slogkit.WithSyslogAppName("app"),
+		slogkit.WithSource(true),
+	)
+	_, file, line, _ := runtime.Caller(0)
+	logger.With("app", "test").WithGroup("http").Warn("message",
+		"status", 200, slog.Group("req", "x-path", "/"), "body", "line1\nline2", "9lives", true, "_", "ok")
+
+	fields := journalFields(t, buf.Bytes())
+	assert.Equal(t, [][2]string{
+		{"MESSAGE", "message"},
+		{"PRIORITY", "4"},
+		{"SYSLOG_IDENTIFIER", "app"},
+		{"CODE_FILE", file},
+		{"CODE_LINE", strconv.Itoa(line + 1)},
+		{"CODE_FUNC", "github.com/hhromic/go-toolkit/slogkit_test.TestHandlerJournal"},
+		{"APP", "test"},
+		{"HTTP_STATUS", "200"},
+		{"HTTP_REQ_X_PATH", "/"},
+		{"HTTP_BODY", "line1\nline2"},
+		{"HTTP_9LIVES", "true"},
+		{"HTTP__", "ok"},
+	}, fields)
+}
+
+func TestHandlerJournalReservedNames(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerJournal, slog.LevelInfo,
+		slogkit.WithSyslogAppName("app"),
+	)
+	logger.Info("message", "message", "spoofed", "priority", 0, "syslog_identifier", "sshd",
+		slog.Group("code", "file", "x.go", "line", 1, "func", "main"), "message_id", "42")
+
+	assert.Equal(t, [][2]string{
+		{"MESSAGE", "message"},
+		{"PRIORITY", "6"},
+		{"SYSLOG_IDENTIFIER", "app"},
+		{"ATTR_MESSAGE", "spoofed"},
+		{"ATTR_PRIORITY", "0"},
+		{"ATTR_SYSLOG_IDENTIFIER", "sshd"},
+		{"ATTR_CODE_FILE", "x.go"},
+		{"ATTR_CODE_LINE", "1"},
+		{"ATTR_CODE_FUNC", "main"},
+		{"MESSAGE_ID", "42"},
+	}, journalFields(t, buf.Bytes()))
+}
+
+func TestHandlerJournalPriority(t *testing.T) {
+	testCases := []struct {
+		level slog.Level
+		want  string
+	}{
+		{level: slogkit.LevelTrace, want: "7"},
+		{level: slog.LevelDebug, want: "7"},
+		{level: slog.LevelInfo, want: "6"},
+		{level: slogkit.LevelNotice, want: "5"},
+		{level: slog.LevelWarn, want: "4"},
+		{level: slog.LevelError, want: "3"},
+		{level: slogkit.LevelFatal, want: "2"},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(slogkit.LevelName(tCase.level), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := slogkit.NewLogger(&buf, slogkit.HandlerJournal, slogkit.LevelTrace)
+			logger.Log(t.Context(), tCase.level, "message", "1", "x")
+			assert.Equal(t, [2]string{"PRIORITY", tCase.want}, journalFields(t, buf.Bytes())[1])
+		})
+	}
+}
+
+func TestJournalWriter(t *testing.T) {
+	addr, conn := listenJournal(t)
+
+	w, err := slogkit.NewJournalWriter(addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerJournal, slog.LevelInfo, slogkit.WithSyslogAppName("app"))
+	logger.Info("message", "key", "val")
+
+	b := make([]byte, 1024)
+	n, err := conn.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "MESSAGE=message\nPRIORITY=6\nSYSLOG_IDENTIFIER=app\nKEY=val\n", string(b[:n]))
+}
+
+func TestJournalWriterNoSocket(t *testing.T) {
+	_, err := slogkit.NewJournalWriter(filepath.Join(t.TempDir(), "missing.sock"))
+	require.Error(t, err)
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxLevelBodySize is the maximum accepted size of a level change request body.
+const maxLevelBodySize = 4096
+
+// LevelController is a runtime-adjustable level that can be read and changed as text.
+// It is implemented by [*slog.LevelVar] and [*ComponentLevels]. For a [*slog.LevelVar],
+// [LevelHandler] uses [LevelName] and [ParseLevel] to also support the custom slogkit levels.
+type LevelController interface {
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+// LevelHandler is an [http.Handler] that exposes a [LevelController] for runtime changes.
+//
+// A GET request responds with the current level as text. A PUT request sets the level from the
+// request body, for example "debug" for a [*slog.LevelVar] or "info,http=debug" for
+// [*ComponentLevels]. If the "ttl" query parameter is set to a duration (for example "10m"),
+// the level automatically reverts to the last level set without a TTL once it expires.
+// Every change is logged as an audit record including who requested it.
+type LevelHandler struct {
+	levels LevelController
+	logger *slog.Logger
+
+	mu    sync.Mutex
+	base  []byte // level to revert to when a temporary change is active
+	timer *time.Timer
+	gen   uint64 // incremented on every change to discard stale reverts
+}
+
+// NewLevelHandler creates a [LevelHandler] for levels that logs audit records to logger,
+// or to [slog.Default] if logger is nil. Request bodies larger than 4096 bytes are rejected.
+func NewLevelHandler(levels LevelController, logger *slog.Logger) *LevelHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LevelHandler{levels: levels, logger: logger, mu: sync.Mutex{}, base: nil, timer: nil, gen: 0}
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		h.serveGet(w)
+	case http.MethodPut:
+		h.servePut(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// Stop cancels any pending automatic revert, keeping the current level.
+func (h *LevelHandler) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.cancelRevert()
+	h.base = nil
+}
+
+func (h *LevelHandler) serveGet(w http.ResponseWriter) {
+	h.mu.Lock()
+	b, err := marshalLevels(h.levels)
+	h.mu.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeLevel(w, b)
+}
+
+func (h *LevelHandler) servePut(w http.ResponseWriter, r *http.Request) {
+	var ttl time.Duration
+
+	if str := r.URL.Query().Get("ttl"); str != "" {
+		var err error
+
+		ttl, err = time.ParseDuration(str)
+		if err != nil || ttl <= 0 {
+			http.Error(w, fmt.Sprintf("invalid ttl %q", str), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLevelBodySize))
+	if err != nil {
+		code := http.StatusBadRequest
+
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			code = http.StatusRequestEntityTooLarge
+		}
+
+		http.Error(w, err.Error(), code)
+
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	old, err := marshalLevels(h.levels)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	err = unmarshalLevels(h.levels, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	cur, _ := marshalLevels(h.levels)
+
+	h.cancelRevert()
+
+	attrs := []slog.Attr{
+		slog.String("old", string(old)),
+		slog.String("new", string(cur)),
+		slog.String("remote_addr", r.RemoteAddr),
+		slog.String("user_agent", r.UserAgent()),
+	}
+
+	if user, _, ok := r.BasicAuth(); ok {
+		attrs = append(attrs, slog.String("user", user))
+	}
+
+	if ttl > 0 {
+		if h.base == nil {
+			h.base = old
+		}
+
+		gen := h.gen
+		h.timer = time.AfterFunc(ttl, func() { h.revert(gen) })
+		attrs = append(attrs, slog.Duration("ttl", ttl), slog.String("revert_to", string(h.base)))
+	} else {
+		h.base = nil
+	}
+
+	h.logger.LogAttrs(r.Context(), slog.LevelInfo, "log level changed", attrs...)
+
+	writeLevel(w, cur)
+}
+
+func (h *LevelHandler) revert(gen uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.base == nil || h.gen != gen {
+		return
+	}
+
+	old, _ := marshalLevels(h.levels)
+
+	err := unmarshalLevels(h.levels, h.base)
+	if err != nil {
+		h.logger.LogAttrs(context.Background(), slog.LevelError, "log level revert failed",
+			slog.String("revert_to", string(h.base)), slog.Any("error", err))
+	} else {
+		h.logger.LogAttrs(context.Background(), slog.LevelInfo, "log level reverted",
+			slog.String("old", string(old)), slog.String("new", string(h.base)))
+	}
+
+	h.base, h.timer = nil, nil
+}
+
+func (h *LevelHandler) cancelRevert() {
+	h.gen++
+
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+	}
+}
+
+func marshalLevels(levels LevelController) ([]byte, error) {
+	if lv, ok := levels.(*slog.LevelVar); ok {
+		return []byte(LevelName(lv.Level())), nil
+	}
+
+	return levels.MarshalText() //nolint:wrapcheck // Transparent wrapper.
+}
+
+func unmarshalLevels(levels LevelController, b []byte) error {
+	if lv, ok := levels.(*slog.LevelVar); ok {
+		lvl, err := ParseLevel(string(b))
+		if err != nil {
+			return err
+		}
+
+		lv.Set(lvl)
+
+		return nil
+	}
+
+	return levels.UnmarshalText(b) //nolint:wrapcheck // Transparent wrapper.
+}
+
+func writeLevel(w http.ResponseWriter, b []byte) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write(append(b, '\n'))
+}
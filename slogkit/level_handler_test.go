@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doLevelRequest(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetBasicAuth("alice", "secret")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestLevelHandler(t *testing.T) {
+	var (
+		levelVar slog.LevelVar
+		audit    bytes.Buffer
+	)
+
+	h := slogkit.NewLevelHandler(&levelVar, slogkit.NewLogger(&audit, slogkit.HandlerText, slog.LevelInfo))
+
+	testCases := []struct {
+		name     string
+		method   string
+		target   string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{name: "Get", method: http.MethodGet, target: "/", body: "", wantCode: 200, wantBody: "INFO\n"},
+		{name: "Put", method: http.MethodPut, target: "/", body: "debug\n", wantCode: 200, wantBody: "DEBUG\n"},
+		{name: "GetAfterPut", method: http.MethodGet, target: "/", body: "", wantCode: 200, wantBody: "DEBUG\n"},
+		{name: "PutInvalid", method: http.MethodPut, target: "/", body: "foo", wantCode: 400, wantBody: ""},
+		{name: "PutInvalidTTL", method: http.MethodPut, target: "/?ttl=foo", body: "warn", wantCode: 400, wantBody: ""},
+		{name: "PutTooLarge", method: http.MethodPut, target: "/", body: "warn," + strings.Repeat("x=info,", 1000), wantCode: 413, wantBody: ""},
+		{name: "Post", method: http.MethodPost, target: "/", body: "warn", wantCode: 405, wantBody: ""},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			rec := doLevelRequest(t, h, tCase.method, tCase.target, tCase.body)
+			assert.Equal(t, tCase.wantCode, rec.Code)
+
+			if tCase.wantBody != "" {
+				assert.Equal(t, tCase.wantBody, rec.Body.String())
+			}
+		})
+	}
+
+	assert.Equal(t, slog.LevelDebug, levelVar.Level())
+	assert.Regexp(t, `msg="log level changed" old=INFO new=DEBUG remote_addr=\S+ user_agent="" user=alice\n$`,
+		audit.String())
+}
+
+func TestLevelHandlerNilLogger(t *testing.T) {
+	var levelVar slog.LevelVar
+
+	h := slogkit.NewLevelHandler(&levelVar, nil)
+
+	rec := doLevelRequest(t, h, http.MethodPut, "/", "warn")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, slog.LevelWarn, levelVar.Level())
+}
+
+func TestLevelHandlerTTL(t *testing.T) {
+	var levelVar slog.LevelVar
+
+	h := slogkit.NewLevelHandler(&levelVar, slog.New(slog.DiscardHandler))
+
+	rec := doLevelRequest(t, h, http.MethodPut, "/?ttl=50ms", "debug")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doLevelRequest(t, h, http.MethodPut, "/?ttl=50ms", "debug-4")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, slog.LevelDebug-4, levelVar.Level())
+
+	// Reverts to the last level set without a TTL.
+	require.Eventually(t, func() bool { return levelVar.Level() == slog.LevelInfo }, time.Second, 5*time.Millisecond)
+
+	rec = doLevelRequest(t, h, http.MethodPut, "/?ttl=50ms", "debug")
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = doLevelRequest(t, h, http.MethodPut, "/", "warn")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// A permanent change cancels the pending revert.
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, slog.LevelWarn, levelVar.Level())
+
+	rec = doLevelRequest(t, h, http.MethodPut, "/?ttl=20ms", "error")
+	require.Equal(t, http.StatusOK, rec.Code)
+	h.Stop()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, slog.LevelError, levelVar.Level())
+}
+
+func TestLevelHandlerComponents(t *testing.T) {
+	levels := slogkit.NewComponentLevels(slogkit.LevelSpec{Default: slog.LevelInfo, Components: nil})
+	h := slogkit.NewLevelHandler(levels, slog.New(slog.DiscardHandler))
+
+	rec := doLevelRequest(t, h, http.MethodPut, "/", "warn,http=debug")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "WARN,http=DEBUG\n", rec.Body.String())
+	assert.Equal(t, slog.LevelDebug, levels.ComponentLevel("http.server"))
+}
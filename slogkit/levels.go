@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Custom logging levels in addition to the standard slog levels.
+const (
+	// LevelTrace is for very verbose diagnostics, below [slog.LevelDebug].
+	LevelTrace = slog.LevelDebug - 4
+	// LevelNotice is for normal but significant events, between [slog.LevelInfo] and [slog.LevelWarn].
+	LevelNotice = slog.LevelInfo + 2
+	// LevelFatal is for unrecoverable errors, above [slog.LevelError]. See [Fatal].
+	LevelFatal = slog.LevelError + 4
+)
+
+// levelName is the full and tint (abbreviated) name of a base level.
+type levelName struct {
+	level  slog.Level
+	name   string
+	short  string
+	color  uint8 // tint ANSI color, only used for custom levels
+	custom bool
+}
+
+// levelNames are the names of all base levels in ascending level order.
+//
+//nolint:gochecknoglobals,mnd // Read-only lookup table with ANSI colors.
+var levelNames = []levelName{
+	{level: LevelTrace, name: "TRACE", short: "TRC", color: 8, custom: true},
+	{level: slog.LevelDebug, name: "DEBUG", short: "DBG", color: 0, custom: false},
+	{level: slog.LevelInfo, name: "INFO", short: "INF", color: 0, custom: false},
+	{level: LevelNotice, name: "NOTICE", short: "NTC", color: 14, custom: true},
+	{level: slog.LevelWarn, name: "WARN", short: "WRN", color: 0, custom: false},
+	{level: slog.LevelError, name: "ERROR", short: "ERR", color: 0, custom: false},
+	{level: LevelFatal, name: "FATAL", short: "FTL", color: 13, custom: true},
+}
+
+// LevelName returns the name of level including the custom slogkit levels. Levels between
+// named levels are named after the closest lower named level plus an offset, for example
+// "NOTICE+1". This mirrors [slog.Level.String] for the standard levels.
+func LevelName(level slog.Level) string {
+	base, offset := baseLevel(level)
+
+	return appendOffset(base.name, offset)
+}
+
+// ParseLevel parses a level name as produced by [LevelName] or [slog.Level.String], case
+// insensitively. For example "trace", "NOTICE", "debug+2" or "FATAL-1". Numeric levels are also
+// accepted, for example "-8".
+func ParseLevel(s string) (slog.Level, error) {
+	str := strings.TrimSpace(s)
+
+	name, offset := str, 0
+	if i := strings.IndexAny(str, "+-"); i > 0 {
+		n, err := strconv.Atoi(str[i:])
+		if err != nil {
+			return 0, fmt.Errorf("%q: parse offset: %w", s, err)
+		}
+
+		name, offset = str[:i], n
+	}
+
+	for _, ln := range levelNames {
+		if strings.EqualFold(name, ln.name) {
+			return ln.level + slog.Level(offset), nil
+		}
+	}
+
+	if n, err := strconv.Atoi(str); err == nil {
+		return slog.Level(n), nil
+	}
+
+	return 0, fmt.Errorf("%q: %w", s, ErrUnknownLevelName)
+}
+
+// ExitFunc is the signature of the process exit function called by [Fatal].
+type ExitFunc = func(code int)
+
+//nolint:gochecknoglobals // Process-wide exit hook, replaceable in tests.
+var exitFunc atomic.Pointer[ExitFunc]
+
+// SetExitFunc sets the function called by [Fatal] after logging, which is [os.Exit] by default,
+// and returns the previous one. Tests can use it to intercept the exit.
+// A nil fn restores [os.Exit].
+func SetExitFunc(fn ExitFunc) ExitFunc {
+	if fn == nil {
+		fn = os.Exit
+	}
+
+	if prev := exitFunc.Swap(&fn); prev != nil {
+		return *prev
+	}
+
+	return os.Exit
+}
+
+// Fatal logs a message at [LevelFatal] using logger and then exits the process with status 1
+// through the function set with [SetExitFunc].
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	fatal(context.Background(), logger, msg, args...)
+}
+
+// FatalContext is like [Fatal] but uses the given context for logging.
+func FatalContext(ctx context.Context, logger *slog.Logger, msg string, args ...any) {
+	fatal(ctx, logger, msg, args...)
+}
+
+func fatal(ctx context.Context, logger *slog.Logger, msg string, args ...any) {
+	if logger.Enabled(ctx, LevelFatal) {
+		var pcs [1]uintptr
+
+		runtime.Callers(3, pcs[:]) //nolint:mnd // Skip Callers, fatal and Fatal/FatalContext.
+
+		r := slog.NewRecord(time.Now(), LevelFatal, msg, pcs[0])
+		r.Add(args...)
+
+		_ = logger.Handler().Handle(ctx, r)
+	}
+
+	exit(1)
+}
+
+// exit exits the process with code through the function set with [SetExitFunc].
+func exit(code int) {
+	if fn := exitFunc.Load(); fn != nil {
+		(*fn)(code)
+
+		return
+	}
+
+	os.Exit(code)
+}
+
+// levelReplaceAttr wraps a replacement function to render the built-in level attribute
+// with the names returned by [LevelName].
+func levelReplaceAttr(fn ReplaceAttrFunc) ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = fn(groups, a)
+
+		if lvl, ok := builtinLevel(groups, a); ok {
+			a.Value = slog.StringValue(LevelName(lvl))
+		}
+
+		return a
+	}
+}
+
+func builtinLevel(groups []string, a slog.Attr) (slog.Level, bool) {
+	if len(groups) != 0 || a.Key != slog.LevelKey || a.Value.Kind() != slog.KindAny {
+		return 0, false
+	}
+
+	lvl, ok := a.Value.Any().(slog.Level)
+
+	return lvl, ok
+}
+
+func baseLevel(level slog.Level) (levelName, slog.Level) {
+	base := levelNames[0]
+	for _, ln := range levelNames {
+		if ln.level <= level {
+			base = ln
+		}
+	}
+
+	return base, level - base.level
+}
+
+func appendOffset(name string, offset slog.Level) string {
+	switch {
+	case offset > 0:
+		return name + "+" + strconv.Itoa(int(offset))
+	case offset < 0:
+		return name + strconv.Itoa(int(offset))
+	default:
+		return name
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelName(t *testing.T) {
+	testCases := []struct {
+		level slog.Level
+		want  string
+	}{
+		{level: slogkit.LevelTrace - 1, want: "TRACE-1"},
+		{level: slogkit.LevelTrace, want: "TRACE"},
+		{level: slogkit.LevelTrace + 2, want: "TRACE+2"},
+		{level: slog.LevelDebug, want: "DEBUG"},
+		{level: slog.LevelInfo, want: "INFO"},
+		{level: slog.LevelInfo + 1, want: "INFO+1"},
+		{level: slogkit.LevelNotice, want: "NOTICE"},
+		{level: slogkit.LevelNotice + 1, want: "NOTICE+1"},
+		{level: slog.LevelWarn, want: "WARN"},
+		{level: slog.LevelError, want: "ERROR"},
+		{level: slogkit.LevelFatal, want: "FATAL"},
+		{level: slogkit.LevelFatal + 4, want: "FATAL+4"},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.want, func(t *testing.T) {
+			assert.Equal(t, tCase.want, slogkit.LevelName(tCase.level))
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		name    string
+		s       string
+		want    slog.Level
+		wantErr error
+	}{
+		{name: "Trace", s: "trace", want: slogkit.LevelTrace, wantErr: nil},
+		{name: "Notice", s: "NOTICE", want: slogkit.LevelNotice, wantErr: nil},
+		{name: "Fatal", s: " Fatal ", want: slogkit.LevelFatal, wantErr: nil},
+		{name: "Standard", s: "warn", want: slog.LevelWarn, wantErr: nil},
+		{name: "PositiveOffset", s: "debug+2", want: slog.LevelDebug + 2, wantErr: nil},
+		{name: "NegativeOffset", s: "FATAL-1", want: slogkit.LevelFatal - 1, wantErr: nil},
+		{name: "Numeric", s: "-8", want: slogkit.LevelTrace, wantErr: nil},
+		{name: "Unknown", s: "verbose", want: 0, wantErr: slogkit.ErrUnknownLevelName},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			lvl, err := slogkit.ParseLevel(tCase.s)
+			require.ErrorIs(t, err, tCase.wantErr)
+			assert.Equal(t, tCase.want, lvl)
+		})
+	}
+
+	t.Run("InvalidOffset", func(t *testing.T) {
+		_, err := slogkit.ParseLevel("info+x")
+		assert.Error(t, err)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for lvl := slogkit.LevelTrace - 2; lvl <= slogkit.LevelFatal+2; lvl++ {
+			got, err := slogkit.ParseLevel(slogkit.LevelName(lvl))
+			require.NoError(t, err)
+			assert.Equal(t, lvl, got)
+		}
+	})
+}
+
+func TestCustomLevelsRendering(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler slogkit.Handler
+		level   slog.Level
+		want    *regexp.Regexp
+	}{
+		{
+			name:    "HandlerText-Trace",
+			handler: slogkit.HandlerText,
+			level:   slogkit.LevelTrace,
+			want:    regexp.MustCompile(`^ts=\S+ level=TRACE msg=message\n$`),
+		},
+		{
+			name:    "HandlerText-NoticeOffset",
+			handler: slogkit.HandlerText,
+			level:   slogkit.LevelNotice + 1,
+			want:    regexp.MustCompile(`^ts=\S+ level=NOTICE\+1 msg=message\n$`),
+		},
+		{
+			name:    "HandlerJSON-Notice",
+			handler: slogkit.HandlerJSON,
+			level:   slogkit.LevelNotice,
+			want:    regexp.MustCompile(`^{"ts":"\S+","level":"NOTICE","msg":"message"}\n$`),
+		},
+		{
+			name:    "HandlerJSON-Fatal",
+			handler: slogkit.HandlerJSON,
+			level:   slogkit.LevelFatal,
+			want:    regexp.MustCompile(`^{"ts":"\S+","level":"FATAL","msg":"message"}\n$`),
+		},
+		{
+			name:    "HandlerTint-Trace",
+			handler: slogkit.HandlerTint,
+			level:   slogkit.LevelTrace,
+			want:    regexp.MustCompile(`^\x1b\[2m[^\x1b]+\x1b\[0m \x1b\[90mTRC\x1b\[0m message\n$`),
+		},
+		{
+			name:    "HandlerTint-Notice",
+			handler: slogkit.HandlerTint,
+			level:   slogkit.LevelNotice,
+			want:    regexp.MustCompile(`^\x1b\[2m[^\x1b]+\x1b\[0m \x1b\[96mNTC\x1b\[0m message\n$`),
+		},
+		{
+			name:    "HandlerTint-Fatal",
+			handler: slogkit.HandlerTint,
+			level:   slogkit.LevelFatal,
+			want:    regexp.MustCompile(`^\x1b\[2m[^\x1b]+\x1b\[0m \x1b\[95mFTL\x1b\[0m message\n$`),
+		},
+		{
+			name:    "HandlerTint-Info",
+			handler: slogkit.HandlerTint,
+			level:   slog.LevelInfo,
+			want:    regexp.MustCompile(`^\x1b\[2m[^\x1b]+\x1b\[0m \x1b\[92mINF\x1b\[0m message\n$`),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			logger := slogkit.NewLogger(&buf, tCase.handler, slogkit.LevelTrace)
+			logger.Log(t.Context(), tCase.level, "message")
+			assert.Regexp(t, tCase.want, buf.String())
+		})
+	}
+}
+
+func TestFatal(t *testing.T) {
+	var code int
+
+	prev := slogkit.SetExitFunc(func(c int) { code = c })
+	t.Cleanup(func() { slogkit.SetExitFunc(prev) })
+
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerText, slog.LevelInfo, slogkit.WithSource(true))
+	slogkit.Fatal(logger, "cannot continue", "key", "val")
+
+	assert.Equal(t, 1, code)
+	assert.Regexp(t,
+		`^ts=\S+ level=FATAL source=\S+/levels_test\.go:\d+ msg="cannot continue" key=val\n$`,
+		buf.String(),
+	)
+
+	// Exits even when the fatal level is disabled.
+	code = 0
+
+	buf.Reset()
+	slogkit.FatalContext(t.Context(), slogkit.NewLogger(&buf, slogkit.HandlerText, slogkit.LevelFatal+1), "disabled")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, buf.String())
+}
+
+func TestCustomLevelsConfig(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "trace,http=notice")
+
+	cfg := slogkit.NewConfig()
+	require.NoError(t, cfg.LoadEnv(""))
+	assert.Equal(t, slogkit.LevelTrace, cfg.Level)
+	assert.Equal(t, map[string]slog.Level{"http": slogkit.LevelNotice}, cfg.Components)
+
+	var level slog.LevelVar
+
+	h := slogkit.NewLevelHandler(&level, slog.New(slog.DiscardHandler))
+
+	rec := doLevelRequest(t, h, http.MethodPut, "/", "notice")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "NOTICE\n", rec.Body.String())
+	assert.Equal(t, slogkit.LevelNotice, level.Level())
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// LoggerNameKey is the attribute key of the names added with [logr.Logger.WithName].
+const LoggerNameKey = "logger"
+
+// logrSink is a [logr.LogSink] backed by an slog Handler.
+type logrSink struct {
+	handler   slog.Handler
+	name      string
+	callDepth int
+}
+
+// NewLogrSink creates a [logr.LogSink] that logs using logger. Verbosity levels are mapped to
+// slog levels as [slog.LevelInfo] minus the verbosity, therefore V(4) logs at [slog.LevelDebug]
+// and V(8) at [LevelTrace]. Errors are logged at [slog.LevelError] with the error as the
+// [ErrorKey] attribute. Names added with WithName are joined with "/" in the [LoggerNameKey]
+// attribute, and values implementing [logr.Marshaler] are logged as returned by MarshalLog.
+func NewLogrSink(logger *slog.Logger) logr.LogSink {
+	return &logrSink{handler: logger.Handler(), name: "", callDepth: 0}
+}
+
+// NewLogr creates a [logr.Logger] that logs using logger. See [NewLogrSink].
+func NewLogr(logger *slog.Logger) logr.Logger {
+	return logr.New(NewLogrSink(logger))
+}
+
+func (s *logrSink) Init(info logr.RuntimeInfo) {
+	s.callDepth += info.CallDepth
+}
+
+func (s *logrSink) Enabled(level int) bool {
+	return s.handler.Enabled(context.Background(), logrLevel(level))
+}
+
+func (s *logrSink) Info(level int, msg string, keysAndValues ...any) {
+	s.log(logrLevel(level), msg, nil, keysAndValues)
+}
+
+func (s *logrSink) Error(err error, msg string, keysAndValues ...any) {
+	s.log(slog.LevelError, msg, err, keysAndValues)
+}
+
+func (s *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
+	s2 := *s
+	s2.handler = s.handler.WithAttrs(logrAttrs(keysAndValues))
+
+	return &s2
+}
+
+func (s *logrSink) WithName(name string) logr.LogSink {
+	s2 := *s
+	if s2.name != "" {
+		s2.name += "/"
+	}
+
+	s2.name += name
+
+	return &s2
+}
+
+func (s *logrSink) WithCallDepth(depth int) logr.LogSink {
+	s2 := *s
+	s2.callDepth += depth
+
+	return &s2
+}
+
+func (s *logrSink) log(level slog.Level, msg string, err error, keysAndValues []any) {
+	ctx := context.Background()
+	if !s.handler.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+
+	runtime.Callers(s.callDepth+3, pcs[:]) //nolint:mnd // Skip Callers, log and Info/Error.
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+
+	if s.name != "" {
+		r.AddAttrs(slog.String(LoggerNameKey, s.name))
+	}
+
+	if err != nil {
+		r.AddAttrs(slog.Any(ErrorKey, err))
+	}
+
+	r.AddAttrs(logrAttrs(keysAndValues)...)
+
+	_ = s.handler.Handle(ctx, r)
+}
+
+// logrLevel returns the slog level of a logr verbosity level.
+func logrLevel(level int) slog.Level {
+	return slog.LevelInfo - slog.Level(level) //nolint:gosec // Verbosity levels are small.
+}
+
+// logrAttrs returns the attributes of logr key/value pairs.
+func logrAttrs(keysAndValues []any) []slog.Attr {
+	attrs := slog.Group("", keysAndValues...).Value.Group()
+
+	for i, a := range attrs {
+		if m, ok := a.Value.Any().(logr.Marshaler); ok && a.Value.Kind() == slog.KindAny {
+			attrs[i].Value = slog.AnyValue(m.MarshalLog())
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/hhromic/go-toolkit/slogkit/slogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type logrUser struct {
+	name, password string
+}
+
+func (u logrUser) MarshalLog() any {
+	return map[string]string{"name": u.name}
+}
+
+func TestNewLogr(t *testing.T) {
+	h := slogtest.NewHandler(slogkit.LevelTrace)
+	logger := slogkit.NewLogr(slog.New(h))
+
+	logger.Info("info", "key", "val")
+	logger.V(4).Info("debug")
+	logger.V(8).Info("trace")
+	logger.V(9).Info("too verbose")
+	logger.WithName("ctrl").WithName("pod").WithValues("ns", "default").
+		Error(errors.New("boom"), "failed", "user", logrUser{name: "john", password: "secret"})
+	logger.Error(nil, "no error")
+
+	h.AssertLogged(t, slog.LevelInfo, "info", "key", "val")
+	h.AssertLogged(t, slog.LevelDebug, "debug")
+	h.AssertLogged(t, slogkit.LevelTrace, "trace")
+	h.AssertNotLogged(t, slogkit.LevelTrace-1, "too verbose")
+	h.AssertLogged(t, slog.LevelError, "failed", "logger", "ctrl/pod", "ns", "default")
+	h.AssertLogged(t, slog.LevelError, "no error")
+
+	rec, _ := h.Find(func(r slogtest.Record) bool { return r.Message == "failed" })
+	assert.EqualError(t, rec.Attrs["err"].Any().(error), "boom") //nolint:forcetypeassert // Test.
+	assert.Equal(t, map[string]string{"name": "john"}, rec.Attrs["user"].Any())
+
+	rec, _ = h.Find(func(r slogtest.Record) bool { return r.Message == "no error" })
+	assert.NotContains(t, rec.Attrs, "err")
+
+	assert.True(t, logger.V(8).Enabled())
+	assert.False(t, logger.V(9).Enabled())
+}
+
+func TestNewLogrSource(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLogr(slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerText, slog.LevelInfo,
+		slogkit.WithSource(true),
+	))
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info("direct")
+	helper := func(l logr.Logger) { l.WithCallDepth(1).Info("helper") }
+	helper(logger)
+
+	assert.Regexp(t, `source=`+file+`:`+strconv.Itoa(line+1)+` msg=direct\n`, buf.String())
+	assert.Regexp(t, `source=`+file+`:`+strconv.Itoa(line+3)+` msg=helper\n`, buf.String())
+}
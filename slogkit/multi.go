@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Sink is an output of a logger created by [NewMultiLogger].
+type Sink struct {
+	// Writer is the output of the sink.
+	Writer io.Writer
+	// Handler is the slogkit handler of the sink.
+	Handler Handler
+	// Leveler is the minimum logging level of the sink.
+	Leveler slog.Leveler
+	// Options are additional options for the sink (see [NewLoggerWithOptions]).
+	Options []Option
+}
+
+// NewMultiLogger creates an slog Logger that outputs every record to all the sinks for which the
+// record level is enabled, each sink with its own writer, slogkit handler, leveler and options.
+// It returns nil if the handler of any sink is unknown. See [NewMultiHandler] for details.
+func NewMultiLogger(sinks ...Sink) *slog.Logger {
+	handlers := make([]slog.Handler, 0, len(sinks))
+
+	for _, s := range sinks {
+		hdl := newLoggerHandler(s.Writer, s.Handler, s.Leveler, s.Options)
+		if hdl == nil {
+			return nil
+		}
+
+		handlers = append(handlers, hdl)
+	}
+
+	return slog.New(NewMultiHandler(handlers...))
+}
+
+// multiHandler is an slog Handler that fans out records to multiple handlers.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiHandler creates an slog Handler that passes every record to all the handlers for which
+// the record level is enabled. It is enabled for a level if any of the handlers is enabled.
+// Attributes and groups are propagated to all the handlers. Errors returned by the handlers are
+// joined and do not prevent the record from being passed to the remaining handlers.
+func NewMultiHandler(handlers ...slog.Handler) slog.Handler {
+	return &multiHandler{handlers: handlers}
+}
+
+func (h *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, hdl := range h.handlers {
+		if hdl.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+
+	for i, hdl := range h.handlers {
+		if !hdl.Enabled(ctx, r.Level) {
+			continue
+		}
+
+		err := hdl.Handle(ctx, r.Clone())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("handler %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, hdl := range h.handlers {
+		handlers[i] = hdl.WithAttrs(attrs)
+	}
+
+	return &multiHandler{handlers: handlers}
+}
+
+func (h *multiHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, hdl := range h.handlers {
+		handlers[i] = hdl.WithGroup(name)
+	}
+
+	return &multiHandler{handlers: handlers}
+}
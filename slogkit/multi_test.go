@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSink = errors.New("sink failure")
+
+type failingHandler struct {
+	slog.Handler
+}
+
+func (failingHandler) Handle(context.Context, slog.Record) error {
+	return errSink
+}
+
+func TestNewMultiLogger(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+
+	logger := slogkit.NewMultiLogger(
+		slogkit.Sink{Writer: &jsonBuf, Handler: slogkit.HandlerJSON, Leveler: slog.LevelInfo, Options: nil},
+		slogkit.Sink{
+			Writer:  &textBuf,
+			Handler: slogkit.HandlerText,
+			Leveler: slog.LevelDebug,
+			Options: []slogkit.Option{slogkit.WithTimeKey(slog.TimeKey)},
+		},
+	)
+	require.NotNil(t, logger)
+
+	logger = logger.With("app", "test").WithGroup("req")
+	logger.Debug("debug message", "id", 1)
+	logger.Info("info message", "id", 2)
+
+	assert.Regexp(t,
+		`^{"ts":"\S+","level":"INFO","msg":"info message","app":"test","req":{"id":2}}\n$`,
+		jsonBuf.String(),
+	)
+	assert.Regexp(t,
+		`^time=\S+ level=DEBUG msg="debug message" app=test req\.id=1\n`+
+			`time=\S+ level=INFO msg="info message" app=test req\.id=2\n$`,
+		textBuf.String(),
+	)
+
+	t.Run("UnknownHandler", func(t *testing.T) {
+		assert.Nil(t, slogkit.NewMultiLogger(
+			slogkit.Sink{Writer: &jsonBuf, Handler: slogkit.HandlerJSON, Leveler: slog.LevelInfo, Options: nil},
+			slogkit.Sink{Writer: &jsonBuf, Handler: -1, Leveler: slog.LevelInfo, Options: nil},
+		))
+	})
+}
+
+func TestMultiHandlerEnabled(t *testing.T) {
+	hdl := slogkit.NewMultiHandler(
+		slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}),  //nolint:exhaustruct_v5
+		slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelDebug}), //nolint:exhaustruct_v5
+	)
+
+	assert.True(t, hdl.Enabled(t.Context(), slog.LevelDebug))
+	assert.True(t, hdl.Enabled(t.Context(), slog.LevelError))
+	assert.False(t, hdl.Enabled(t.Context(), slogkit.LevelTrace))
+	assert.False(t, slogkit.NewMultiHandler().Enabled(t.Context(), slog.LevelError))
+}
+
+func TestMultiHandlerErrors(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+
+	hdl := slogkit.NewMultiHandler(
+		slog.NewTextHandler(&buf1, nil),
+		failingHandler{Handler: slog.NewTextHandler(&bytes.Buffer{}, nil)},
+		slog.NewTextHandler(&buf2, nil),
+	)
+
+	err := hdl.Handle(t.Context(), slog.NewRecord(time.Time{}, slog.LevelInfo, "message", 0))
+	require.ErrorIs(t, err, errSink)
+	assert.ErrorContains(t, err, "handler 1")
+	assert.Contains(t, buf1.String(), "msg=message")
+	assert.Contains(t, buf2.String(), "msg=message")
+}
+
+func TestMultiHandlerSlogtest(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+
+	results := func(buf *bytes.Buffer) []map[string]any {
+		var out []map[string]any
+
+		for line := range strings.Lines(buf.String()) {
+			var m map[string]any
+
+			require.NoError(t, json.Unmarshal([]byte(line), &m))
+
+			out = append(out, m)
+		}
+
+		return out
+	}
+
+	hdl := slogkit.NewMultiHandler(slog.NewJSONHandler(&buf1, nil), slog.NewJSONHandler(&buf2, nil))
+	require.NoError(t, slogtest.TestHandler(hdl, func() []map[string]any { return results(&buf1) }))
+	assert.Equal(t, buf1.String(), buf2.String())
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultTimeKey is the key used by default for the built-in [slog.TimeKey] attribute.
+const DefaultTimeKey = "ts"
+
+// ReplaceAttrFunc is the signature of [slog.HandlerOptions.ReplaceAttr] functions.
+type ReplaceAttrFunc = func(groups []string, a slog.Attr) slog.Attr
+
+// Option configures the loggers created by [NewLoggerWithOptions].
+// Options are applied in order and consistently to all slogkit handler kinds.
+type Option func(*options)
+
+type options struct {
+	addSource    bool
+	timeKey      string
+	timeFormat   func(time.Time) slog.Value
+	utc          bool
+	replaceAttrs []ReplaceAttrFunc
+	gcpProjectID string
+
+	syslogFacility SyslogFacility
+	syslogAppName  string
+	syslogRFC3164  bool
+
+	autoPolicy AutoPolicy
+	autoJSON   bool
+
+	tintTheme TintTheme
+	noColor   bool
+}
+
+// WithSource enables or disables the built-in [slog.SourceKey] attribute with the source code
+// location of the log call. Source locations are disabled by default.
+func WithSource(enabled bool) Option {
+	return func(o *options) {
+		o.addSource = enabled
+	}
+}
+
+// WithTimeKey sets the key used for the built-in [slog.TimeKey] attribute.
+// The default is [DefaultTimeKey], use [slog.TimeKey] to keep the standard key.
+func WithTimeKey(key string) Option {
+	return func(o *options) {
+		o.timeKey = key
+	}
+}
+
+// WithTimeLayout formats the built-in [slog.TimeKey] attribute as a string using the given
+// [time.Time.Format] layout, for example [time.RFC3339Nano].
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeFormat = func(t time.Time) slog.Value {
+			return slog.StringValue(t.Format(layout))
+		}
+	}
+}
+
+// WithTimeUnixMilli formats the built-in [slog.TimeKey] attribute as an integer number
+// of milliseconds since the Unix epoch.
+func WithTimeUnixMilli() Option {
+	return func(o *options) {
+		o.timeFormat = func(t time.Time) slog.Value {
+			return slog.Int64Value(t.UnixMilli())
+		}
+	}
+}
+
+// WithTimeUTC converts the built-in [slog.TimeKey] attribute to UTC before formatting it.
+func WithTimeUTC() Option {
+	return func(o *options) {
+		o.utc = true
+	}
+}
+
+// WithReplaceAttr appends fn to the chain of attribute replacement functions. The functions are
+// called in the order they were added and before the built-in time attribute options are applied,
+// therefore they always observe the standard slog keys. The chain stops when an attribute is
+// removed (its key is empty). See [slog.HandlerOptions.ReplaceAttr] for details.
+func WithReplaceAttr(fn ReplaceAttrFunc) Option {
+	return func(o *options) {
+		o.replaceAttrs = append(o.replaceAttrs, fn)
+	}
+}
+
+// WithGCPProjectID sets the Google Cloud project ID used by [HandlerGCP] to qualify trace IDs
+// as "projects/<id>/traces/<trace>", which is required to correlate logs with traces.
+func WithGCPProjectID(id string) Option {
+	return func(o *options) {
+		o.gcpProjectID = id
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		addSource:    false,
+		timeKey:      DefaultTimeKey,
+		timeFormat:   nil,
+		utc:          false,
+		replaceAttrs: nil,
+		gcpProjectID: "",
+
+		syslogFacility: SyslogUser,
+		syslogAppName:  "",
+		syslogRFC3164:  false,
+
+		autoPolicy: DefaultAutoPolicy,
+		autoJSON:   false,
+
+		tintTheme: TintTheme{Levels: nil, Keys: nil},
+		noColor:   false,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// replaceAttr returns the combined attribute replacement function for the options.
+func (o *options) replaceAttr() ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		for _, fn := range o.replaceAttrs {
+			if a = fn(groups, a); a.Key == "" {
+				return a
+			}
+		}
+
+		if len(groups) == 0 && a.Key == slog.TimeKey {
+			if t, ok := a.Value.Any().(time.Time); ok && a.Value.Kind() == slog.KindTime {
+				if o.utc {
+					t = t.UTC()
+					a.Value = slog.TimeValue(t)
+				}
+
+				if o.timeFormat != nil {
+					a.Value = o.timeFormat(t)
+				}
+			}
+
+			a.Key = o.timeKey
+		}
+
+		return a
+	}
+}
+
+// handlerOptions returns standard slog handler options for the options and the given leveler.
+func (o *options) handlerOptions(leveler slog.Leveler) *slog.HandlerOptions {
+	return &slog.HandlerOptions{
+		AddSource:   o.addSource,
+		Level:       leveler,
+		ReplaceAttr: o.replaceAttr(),
+	}
+}
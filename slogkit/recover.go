@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"time"
+)
+
+// Attribute keys of the records logged for recovered panics.
+const (
+	PanicKey = "panic"
+	StackKey = "stack"
+)
+
+// PanicMessage is the message of the records logged by [Recover], [RecoverContext] and [Go].
+const PanicMessage = "panic recovered"
+
+// panicExitCode is the exit status used by [PanicExit], the same as for unrecovered panics.
+const panicExitCode = 2
+
+// PanicPolicy is what happens after a recovered panic is logged.
+type PanicPolicy int
+
+// Supported panic policies.
+const (
+	// PanicContinue logs the panic at [slog.LevelError] and returns normally.
+	PanicContinue PanicPolicy = iota
+	// PanicRepanic logs the panic at [slog.LevelError] and panics again with the same value.
+	PanicRepanic
+	// PanicExit logs the panic at [LevelFatal] and exits the process with status 2
+	// through the function set with [SetExitFunc].
+	PanicExit
+)
+
+// String returns a name for the panic policy.
+func (p PanicPolicy) String() string {
+	switch p {
+	case PanicContinue:
+		return "continue"
+	case PanicRepanic:
+		return "repanic"
+	case PanicExit:
+		return "exit"
+	default:
+		return fmt.Sprintf("PanicPolicy(%d)", p)
+	}
+}
+
+// RecoverOption is an option for [Recover], [RecoverContext] and [Go].
+type RecoverOption func(*recoverOptions)
+
+// recoverOptions are the options of a panic recovery.
+type recoverOptions struct {
+	policy PanicPolicy
+}
+
+// WithPanicPolicy sets what happens after a recovered panic is logged. The default is [PanicContinue].
+func WithPanicPolicy(policy PanicPolicy) RecoverOption {
+	return func(o *recoverOptions) {
+		o.policy = policy
+	}
+}
+
+// Recover recovers a panic and logs it using logger as a single record with the [PanicMessage]
+// message, the panic value as the [PanicKey] attribute and the stack of the panicking goroutine
+// as the [StackKey] attribute. The source of the record is the function that panicked.
+// It must be called directly by a deferred statement, for example:
+//
+//	defer slogkit.Recover(logger)
+//
+// If the handler of logger panics or fails while logging, the panic is written to [os.Stderr]
+// in the format of the runtime instead. See [WithPanicPolicy] for what happens afterwards.
+func Recover(logger *slog.Logger, opts ...RecoverOption) {
+	if v := recover(); v != nil {
+		logPanic(context.Background(), logger, v, opts)
+	}
+}
+
+// RecoverContext is like [Recover] but uses the given context for logging, therefore the
+// record includes the attributes added to ctx with [WithAttrs] when using slogkit loggers.
+func RecoverContext(ctx context.Context, logger *slog.Logger, opts ...RecoverOption) {
+	if v := recover(); v != nil {
+		logPanic(ctx, logger, v, opts)
+	}
+}
+
+// Go runs fn with ctx in a new goroutine that recovers and logs panics using logger
+// as [RecoverContext] does.
+func Go(ctx context.Context, logger *slog.Logger, fn func(ctx context.Context), opts ...RecoverOption) {
+	go func() {
+		defer RecoverContext(ctx, logger, opts...)
+
+		fn(ctx)
+	}()
+}
+
+func logPanic(ctx context.Context, logger *slog.Logger, v any, opts []RecoverOption) {
+	o := recoverOptions{policy: PanicContinue}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	level := slog.LevelError
+	if o.policy == PanicExit {
+		level = LevelFatal
+	}
+
+	stack := debug.Stack()
+
+	if logger.Enabled(ctx, level) {
+		// Skip Callers, callerPC, logPanic and Recover/RecoverContext, then the runtime frames
+		// of the panic to get to the function that panicked.
+		r := slog.NewRecord(time.Now(), level, PanicMessage, callerPC(4, "runtime.")) //nolint:mnd
+		r.AddAttrs(slog.Any(PanicKey, v), slog.String(StackKey, string(stack)))
+
+		if err := safeHandle(ctx, logger.Handler(), r); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "panic: %v\n\n%s\nslogkit: logging the panic failed: %v\n", v, stack, err)
+		}
+	}
+
+	switch o.policy {
+	case PanicContinue:
+	case PanicRepanic:
+		panic(v)
+	case PanicExit:
+		exit(panicExitCode)
+	}
+}
+
+// safeHandle passes r to h, returning a panic of h as an error instead of propagating it.
+func safeHandle(ctx context.Context, h slog.Handler, r slog.Record) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("%w: %v", ErrHandlerPanicked, v)
+		}
+	}()
+
+	return h.Handle(ctx, r) //nolint:wrapcheck // Transparent handler.
+}
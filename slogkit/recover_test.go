@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/hhromic/go-toolkit/slogkit/slogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panicHandler is an slog Handler that panics when handling records.
+type panicHandler struct {
+	slog.Handler
+}
+
+func (panicHandler) Handle(context.Context, slog.Record) error {
+	panic("handler failure")
+}
+
+func TestPanicPolicyString(t *testing.T) {
+	assert.Equal(t, "continue", slogkit.PanicContinue.String())
+	assert.Equal(t, "repanic", slogkit.PanicRepanic.String())
+	assert.Equal(t, "exit", slogkit.PanicExit.String())
+	assert.Equal(t, "PanicPolicy(9)", slogkit.PanicPolicy(9).String())
+}
+
+func TestRecover(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+
+	var line int
+
+	assert.NotPanics(t, func() {
+		defer slogkit.Recover(slog.New(h))
+
+		_, _, line, _ = runtime.Caller(0)
+		panic(errors.New("boom"))
+	})
+
+	rec := h.WaitLogged(t, time.Second, slog.LevelError, slogkit.PanicMessage)
+	assert.EqualError(t, rec.Attrs[slogkit.PanicKey].Any().(error), "boom") //nolint:forcetypeassert // Test.
+	assert.Contains(t, rec.Attrs[slogkit.StackKey].String(), "slogkit_test.TestRecover.func1()")
+
+	frame, _ := runtime.CallersFrames([]uintptr{rec.PC}).Next()
+	assert.Equal(t, "github.com/hhromic/go-toolkit/slogkit_test.TestRecover.func1", frame.Function)
+	assert.Equal(t, line+1, frame.Line)
+
+	// Nothing is logged without a panic.
+	h.Reset()
+	func() {
+		defer slogkit.Recover(slog.New(h))
+	}()
+	assert.Zero(t, h.Len())
+}
+
+func TestRecoverContext(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	ctx := slogkit.WithAttrs(t.Context(), "request_id", "r1")
+
+	func() {
+		defer slogkit.RecoverContext(ctx, slog.New(slogkit.NewContextHandler(h)))
+
+		panic("boom")
+	}()
+
+	h.AssertLogged(t, slog.LevelError, slogkit.PanicMessage, slogkit.PanicKey, "boom", "request_id", "r1")
+}
+
+func TestRecoverPolicies(t *testing.T) {
+	t.Run("Repanic", func(t *testing.T) {
+		h := slogtest.NewHandler(nil)
+
+		assert.PanicsWithValue(t, "boom", func() {
+			defer slogkit.Recover(slog.New(h), slogkit.WithPanicPolicy(slogkit.PanicRepanic))
+
+			panic("boom")
+		})
+		h.AssertLogged(t, slog.LevelError, slogkit.PanicMessage, slogkit.PanicKey, "boom")
+	})
+
+	t.Run("Exit", func(t *testing.T) {
+		code := -1
+		prev := slogkit.SetExitFunc(func(c int) { code = c })
+		t.Cleanup(func() { slogkit.SetExitFunc(prev) })
+
+		h := slogtest.NewHandler(nil)
+
+		func() {
+			defer slogkit.Recover(slog.New(h), slogkit.WithPanicPolicy(slogkit.PanicExit))
+
+			panic("boom")
+		}()
+
+		assert.Equal(t, 2, code)
+		h.AssertLogged(t, slogkit.LevelFatal, slogkit.PanicMessage, slogkit.PanicKey, "boom")
+	})
+}
+
+func TestRecoverHandlerPanic(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stderr := os.Stderr
+	os.Stderr = w
+
+	t.Cleanup(func() { os.Stderr = stderr })
+
+	assert.NotPanics(t, func() {
+		defer slogkit.Recover(slog.New(panicHandler{Handler: slog.NewTextHandler(io.Discard, nil)}))
+
+		panic("boom")
+	})
+
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Regexp(t, `^panic: boom\n\ngoroutine \d+ \[running\]:\n(?s:.*)`+
+		`slogkit: logging the panic failed: handler panicked: handler failure\n$`, string(out))
+}
+
+func TestGo(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	logger := slog.New(slogkit.NewContextHandler(h))
+	ctx := slogkit.WithAttrs(t.Context(), "worker", 1)
+
+	slogkit.Go(ctx, logger, func(ctx context.Context) {
+		logger.InfoContext(ctx, "working")
+
+		var m map[string]int
+		m["key"]++
+	})
+
+	h.WaitLogged(t, 5*time.Second, slog.LevelInfo, "working", "worker", 1)
+
+	rec := h.WaitLogged(t, 5*time.Second, slog.LevelError, slogkit.PanicMessage, "worker", 1)
+	assert.EqualError(t, rec.Attrs[slogkit.PanicKey].Any().(error), //nolint:forcetypeassert // Test.
+		"assignment to entry in nil map")
+	assert.Contains(t, rec.Attrs[slogkit.StackKey].String(), "slogkit_test.TestGo.func1(")
+
+	frame, _ := runtime.CallersFrames([]uintptr{rec.PC}).Next()
+	assert.Equal(t, "github.com/hhromic/go-toolkit/slogkit_test.TestGo.func1", frame.Function,
+		"source "+frame.File+":"+strconv.Itoa(frame.Line))
+}
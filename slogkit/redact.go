@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactedValue is the replacement of redacted values.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactKeyPattern matches the keys of commonly sensitive attributes. The word "token"
+// only matches as the whole key or its last segment, such as "auth_token" or "accessToken",
+// to not redact keys such as "max_tokens" or "token_count".
+//
+//nolint:gochecknoglobals // Read-only compiled pattern.
+var DefaultRedactKeyPattern = regexp.MustCompile(
+	`(?i)passw(or)?d|passwd|secret|(^|[_.-])token$|(?-i:[a-z0-9]Token$)|` +
+		`api[-_]?key|authorization|cookie|session[-_]?id|credential|private[-_]?key`,
+)
+
+//nolint:gochecknoglobals // Read-only compiled patterns.
+var (
+	jwtPattern        = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	emailPattern      = regexp.MustCompile(`\b([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})\b`)
+)
+
+// Secret is a string that is always logged and formatted as [RedactedValue].
+type Secret string
+
+// LogValue implements [slog.LogValuer].
+func (Secret) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+// String implements [fmt.Stringer].
+func (Secret) String() string {
+	return RedactedValue
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (Secret) MarshalText() ([]byte, error) {
+	return []byte(RedactedValue), nil
+}
+
+// RedactConfig configures the attribute redaction of [Redact] and [WithRedaction].
+type RedactConfig struct {
+	// KeyPattern matches the keys of attributes whose values are replaced with [RedactedValue].
+	// Attributes inside a group whose name matches are also redacted.
+	// [DefaultRedactKeyPattern] is used if nil.
+	KeyPattern *regexp.Regexp
+	// ValueRedactors are applied in order to string and error values of all attributes,
+	// including the message. [RedactJWT], [RedactCardNumbers] and [RedactEmails] are used if nil,
+	// use an empty slice to disable value redaction.
+	ValueRedactors []func(string) string
+}
+
+// Redact returns an attribute replacement function that redacts sensitive values using cfg.
+// It can be used with [WithReplaceAttr] or directly in [slog.HandlerOptions].
+// Groups are descended into and [slog.LogValuer] values are resolved before redaction.
+func Redact(cfg RedactConfig) ReplaceAttrFunc {
+	if cfg.KeyPattern == nil {
+		cfg.KeyPattern = DefaultRedactKeyPattern
+	}
+
+	if cfg.ValueRedactors == nil {
+		cfg.ValueRedactors = []func(string) string{RedactJWT, RedactCardNumbers, RedactEmails}
+	}
+
+	return func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) != 0 || (a.Key != slog.TimeKey && a.Key != slog.LevelKey && a.Key != slog.SourceKey) {
+			a.Value = cfg.redactValue(groups, a.Key, a.Value.Resolve())
+		}
+
+		return a
+	}
+}
+
+// WithRedaction is an option that redacts sensitive attribute values using cfg. See [Redact].
+func WithRedaction(cfg RedactConfig) Option {
+	return WithReplaceAttr(Redact(cfg))
+}
+
+func (cfg RedactConfig) redactValue(groups []string, key string, v slog.Value) slog.Value {
+	if cfg.KeyPattern.MatchString(key) || slices.ContainsFunc(groups, cfg.KeyPattern.MatchString) {
+		return slog.StringValue(RedactedValue)
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Only strings, groups and errors can be redacted.
+	case slog.KindString:
+		if s, ok := cfg.redactString(v.String()); ok {
+			return slog.StringValue(s)
+		}
+	case slog.KindGroup:
+		attrs := v.Group()
+		out := make([]slog.Attr, len(attrs))
+		inner := append(slices.Clip(groups), key)
+
+		for i, ga := range attrs {
+			out[i] = slog.Attr{Key: ga.Key, Value: cfg.redactValue(inner, ga.Key, ga.Value.Resolve())}
+		}
+
+		return slog.GroupValue(out...)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			if s, ok := cfg.redactString(err.Error()); ok {
+				return slog.StringValue(s)
+			}
+		}
+	}
+
+	return v
+}
+
+func (cfg RedactConfig) redactString(s string) (string, bool) {
+	out := s
+	for _, fn := range cfg.ValueRedactors {
+		out = fn(out)
+	}
+
+	return out, out != s
+}
+
+// RedactJWT replaces JSON Web Tokens in s with [RedactedValue].
+func RedactJWT(s string) string {
+	if !strings.Contains(s, "eyJ") {
+		return s
+	}
+
+	return jwtPattern.ReplaceAllLiteralString(s, RedactedValue)
+}
+
+// RedactCardNumbers replaces payment card numbers in s, optionally separated with spaces or
+// dashes and validated with the Luhn algorithm, with [RedactedValue].
+func RedactCardNumbers(s string) string {
+	return cardNumberPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if luhnValid(m) {
+			return RedactedValue
+		}
+
+		return m
+	})
+}
+
+// RedactEmails partially masks email addresses in s, keeping the first character of the local
+// part and the domain, for example "j***@example.com".
+func RedactEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
+
+// luhnValid reports whether the digits in s form a number of 13 to 19 digits
+// with a valid Luhn checksum.
+func luhnValid(s string) bool {
+	var sum, n int
+
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if n%2 == 1 {
+			if d *= 2; d > 9 { //nolint:mnd // Luhn doubling.
+				d -= 9
+			}
+		}
+
+		sum += d
+		n++
+	}
+
+	return n >= 13 && n <= 19 && sum%10 == 0 //nolint:mnd // Card number lengths.
+}
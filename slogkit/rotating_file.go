@@ -0,0 +1,408 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults and formats used by [RotatingFile].
+const (
+	rotatingFileMode   = 0o600
+	rotatingTimeLayout = "2006-01-02T15-04-05.000"
+	compressSuffix     = ".gz"
+)
+
+// RotatingFileConfig configures a [RotatingFile]. The zero value never rotates nor removes files.
+type RotatingFileConfig struct {
+	// MaxSize is the size in bytes after which the file is rotated, 0 means no size limit.
+	MaxSize int64
+	// Interval is the period of time-based rotation, 0 means no time-based rotation.
+	// Rotations happen at multiples of Interval since the zero time, for example every day at
+	// midnight UTC for 24h, and only when the file is written.
+	Interval time.Duration
+	// MaxBackups is the maximum number of rotated files to keep, 0 means keeping all.
+	MaxBackups int
+	// MaxAge is the maximum age of rotated files to keep, 0 means keeping all.
+	MaxAge time.Duration
+	// Compress enables gzip compression of rotated files.
+	Compress bool
+	// ReopenOnSIGHUP enables reopening the file when the process receives SIGHUP,
+	// for compatibility with external rotation tools such as logrotate. It has no effect on
+	// platforms without SIGHUP, such as Windows.
+	ReopenOnSIGHUP bool
+	// Now returns the current time, [time.Now] if nil. It is useful for testing.
+	Now func() time.Time
+}
+
+// RotatingFile is an [io.WriteCloser] that writes to a file and rotates it by size and time.
+// Rotated files are renamed in the same directory as "name-<timestamp>.ext", with the timestamp
+// in UTC, then optionally compressed and removed according to the retention settings in the
+// background. A RotatingFile is safe for concurrent use.
+type RotatingFile struct {
+	path string
+	cfg  RotatingFileConfig
+
+	mu       sync.Mutex
+	closed   bool
+	file     *os.File // nil after failing to open, retried on next write
+	size     int64
+	rotateAt time.Time // zero without time-based rotation
+
+	mill     chan struct{}
+	sighup   chan os.Signal
+	wg       sync.WaitGroup
+	millMu   sync.Mutex
+	millErrs []error
+}
+
+// NewRotatingFile opens or creates the file at path for appending and returns a [RotatingFile]
+// that writes to it using cfg. The file must be closed with [RotatingFile.Close] after use.
+func NewRotatingFile(path string, cfg RotatingFileConfig) (*RotatingFile, error) {
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	rf := &RotatingFile{ //nolint:exhaustruct_v5 // Zero values are valid.
+		path: path,
+		cfg:  cfg,
+		mill: make(chan struct{}, 1),
+	}
+
+	err := rf.open()
+	if err != nil {
+		return nil, err
+	}
+
+	rf.wg.Go(rf.runMill)
+
+	if cfg.ReopenOnSIGHUP {
+		rf.sighup = make(chan os.Signal, 1)
+		notifySIGHUP(rf.sighup)
+		rf.wg.Go(rf.runSIGHUP)
+	}
+
+	return rf, nil
+}
+
+// Write implements [io.Writer]. It rotates the file before writing if the write would exceed
+// the maximum size or the rotation interval has elapsed. A single write is never split.
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return 0, fmt.Errorf("write: %w", os.ErrClosed)
+	}
+
+	if rf.file == nil {
+		err := rf.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	sizeExceeded := rf.cfg.MaxSize > 0 && rf.size > 0 && rf.size+int64(len(p)) > rf.cfg.MaxSize
+	if sizeExceeded || (!rf.rotateAt.IsZero() && !rf.cfg.Now().Before(rf.rotateAt)) {
+		err := rf.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+
+	return n, err //nolint:wrapcheck // Transparent writer.
+}
+
+// Rotate rotates the file immediately.
+func (rf *RotatingFile) Rotate() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return fmt.Errorf("rotate: %w", os.ErrClosed)
+	}
+
+	return rf.rotate()
+}
+
+// Reopen closes and reopens the file at the configured path, creating it if needed.
+// It is used after the file was moved by an external rotation tool.
+func (rf *RotatingFile) Reopen() error {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if rf.closed {
+		return fmt.Errorf("reopen: %w", os.ErrClosed)
+	}
+
+	err := rf.closeFile()
+	if err != nil {
+		return err
+	}
+
+	return rf.open()
+}
+
+// Close closes the file and waits for pending background compression and removal of rotated
+// files. It returns any error of closing the file or of the background work.
+func (rf *RotatingFile) Close() error {
+	rf.mu.Lock()
+
+	if rf.closed {
+		rf.mu.Unlock()
+
+		return fmt.Errorf("close: %w", os.ErrClosed)
+	}
+
+	err := rf.closeFile()
+	rf.closed = true
+
+	if rf.sighup != nil {
+		signal.Stop(rf.sighup)
+		close(rf.sighup)
+	}
+
+	close(rf.mill)
+	rf.mu.Unlock()
+
+	rf.wg.Wait()
+
+	rf.millMu.Lock()
+	defer rf.millMu.Unlock()
+
+	return errors.Join(append([]error{err}, rf.millErrs...)...)
+}
+
+// open opens the file, must be called with the lock held.
+func (rf *RotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(rf.path), 0o750) //nolint:mnd // Directory permissions.
+	if err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	file, err := os.OpenFile(rf.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, rotatingFileMode)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("stat: %w", err)
+	}
+
+	rf.file, rf.size = file, info.Size()
+
+	if rf.cfg.Interval > 0 {
+		rf.rotateAt = rf.cfg.Now().Truncate(rf.cfg.Interval).Add(rf.cfg.Interval)
+	}
+
+	return nil
+}
+
+// closeFile closes the file if it is open, must be called with the lock held.
+func (rf *RotatingFile) closeFile() error {
+	if rf.file == nil {
+		return nil
+	}
+
+	err := rf.file.Close()
+	rf.file = nil
+
+	if err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+
+	return nil
+}
+
+// rotate renames the current file to a backup name and opens a new file, must be called with
+// the lock held. If renaming fails, the current file is reopened to keep writing to it.
+// If opening fails, the file is opened again on the next write.
+func (rf *RotatingFile) rotate() error {
+	err := rf.closeFile()
+	if err != nil {
+		return err
+	}
+
+	backup := rf.backupName(rf.cfg.Now())
+	for i := 1; fileExists(backup) || fileExists(backup+compressSuffix); i++ {
+		backup = rf.backupName(rf.cfg.Now().Add(time.Duration(i) * time.Millisecond))
+	}
+
+	err = os.Rename(rf.path, backup)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Join(fmt.Errorf("rename: %w", err), rf.open())
+	}
+
+	err = rf.open()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case rf.mill <- struct{}{}:
+	default: // Already pending.
+	}
+
+	return nil
+}
+
+func (rf *RotatingFile) runSIGHUP() {
+	for range rf.sighup {
+		_ = rf.Reopen()
+	}
+}
+
+func (rf *RotatingFile) runMill() {
+	for range rf.mill {
+		err := rf.millOnce()
+		if err != nil {
+			rf.millMu.Lock()
+			rf.millErrs = append(rf.millErrs, err)
+			rf.millMu.Unlock()
+		}
+	}
+}
+
+// millOnce compresses and removes rotated files according to the configuration.
+func (rf *RotatingFile) millOnce() error {
+	backups, err := rf.backups()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	now := rf.cfg.Now()
+
+	for i, b := range backups {
+		if (rf.cfg.MaxBackups > 0 && i >= rf.cfg.MaxBackups) ||
+			(rf.cfg.MaxAge > 0 && now.Sub(b.ts) > rf.cfg.MaxAge) {
+			err = os.Remove(b.path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("remove: %w", err))
+			}
+
+			continue
+		}
+
+		if rf.cfg.Compress && !strings.HasSuffix(b.path, compressSuffix) {
+			err = compressFile(b.path)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type rotatedFile struct {
+	path string
+	ts   time.Time
+}
+
+// backups returns the rotated files of the file sorted from newest to oldest.
+func (rf *RotatingFile) backups() ([]rotatedFile, error) {
+	dir := filepath.Dir(rf.path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	prefix, ext := rf.backupParts()
+
+	var backups []rotatedFile
+
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), compressSuffix)
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+
+		ts, err := time.Parse(rotatingTimeLayout, name[len(prefix):len(name)-len(ext)])
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, rotatedFile{path: filepath.Join(dir, e.Name()), ts: ts})
+	}
+
+	slices.SortFunc(backups, func(a, b rotatedFile) int { return b.ts.Compare(a.ts) })
+
+	return backups, nil
+}
+
+func (rf *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := rf.backupParts()
+
+	return filepath.Join(filepath.Dir(rf.path), prefix+t.UTC().Format(rotatingTimeLayout)+ext)
+}
+
+func (rf *RotatingFile) backupParts() (string, string) {
+	base := filepath.Base(rf.path)
+	ext := filepath.Ext(base)
+
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// compressFile compresses the file at path to path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path) //nolint:gosec // Path of a rotated file.
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer src.Close()
+
+	tmp := path + compressSuffix + ".tmp"
+
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, rotatingFileMode)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	gz := gzip.NewWriter(dst)
+
+	_, err = io.Copy(gz, src)
+	err = errors.Join(err, gz.Close(), dst.Close())
+
+	if err == nil {
+		err = os.Rename(tmp, path+compressSuffix)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("compress %s: %w", path, err)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+
+	return err == nil
+}
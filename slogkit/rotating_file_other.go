@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package slogkit
+
+import (
+	"os"
+)
+
+// notifySIGHUP does nothing, SIGHUP is not delivered on this platform.
+func notifySIGHUP(chan<- os.Signal) {}
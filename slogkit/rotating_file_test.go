@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func dirFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	var r io.Reader = f
+
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		require.NoError(t, err)
+
+		r = gz
+	}
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)} //nolint:exhaustruct_v5
+
+	rf, err := slogkit.NewRotatingFile(filepath.Join(dir, "app.log"), slogkit.RotatingFileConfig{ //nolint:exhaustruct_v5
+		MaxSize: 10,
+		Now:     clock.Now,
+	})
+	require.NoError(t, err)
+
+	for _, s := range []string{"12345\n", "abcd\n", "xyz\n", "0123456789abc\n"} {
+		_, err = rf.Write([]byte(s))
+		require.NoError(t, err)
+		clock.Advance(time.Second)
+	}
+
+	require.NoError(t, rf.Close())
+	require.ErrorIs(t, rf.Close(), os.ErrClosed)
+
+	_, err = rf.Write([]byte("closed"))
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	assert.Equal(t, []string{
+		"app-2024-01-02T03-04-06.000.log",
+		"app-2024-01-02T03-04-08.000.log",
+		"app.log",
+	}, dirFiles(t, dir))
+	assert.Equal(t, "12345\n", readFile(t, filepath.Join(dir, "app-2024-01-02T03-04-06.000.log")))
+	assert.Equal(t, "abcd\nxyz\n", readFile(t, filepath.Join(dir, "app-2024-01-02T03-04-08.000.log")))
+	assert.Equal(t, "0123456789abc\n", readFile(t, filepath.Join(dir, "app.log")))
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 23, 30, 0, 0, time.UTC)} //nolint:exhaustruct_v5
+
+	rf, err := slogkit.NewRotatingFile(filepath.Join(dir, "app.log"), slogkit.RotatingFileConfig{ //nolint:exhaustruct_v5
+		Interval: 24 * time.Hour,
+		Now:      clock.Now,
+	})
+	require.NoError(t, err)
+
+	_, err = rf.Write([]byte("day 1\n"))
+	require.NoError(t, err)
+
+	clock.Advance(20 * time.Minute)
+
+	_, err = rf.Write([]byte("still day 1\n"))
+	require.NoError(t, err)
+
+	clock.Advance(20 * time.Minute)
+
+	_, err = rf.Write([]byte("day 2\n"))
+	require.NoError(t, err)
+	require.NoError(t, rf.Close())
+
+	assert.Equal(t, []string{"app-2024-01-03T00-10-00.000.log", "app.log"}, dirFiles(t, dir))
+	assert.Equal(t, "day 1\nstill day 1\n", readFile(t, filepath.Join(dir, "app-2024-01-03T00-10-00.000.log")))
+	assert.Equal(t, "day 2\n", readFile(t, filepath.Join(dir, "app.log")))
+}
+
+func TestRotatingFileRetention(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)} //nolint:exhaustruct_v5
+
+	rf, err := slogkit.NewRotatingFile(filepath.Join(dir, "app"), slogkit.RotatingFileConfig{ //nolint:exhaustruct_v5
+		MaxBackups: 4,
+		MaxAge:     80 * time.Minute,
+		Compress:   true,
+		Now:        clock.Now,
+	})
+	require.NoError(t, err)
+
+	for i := range 5 {
+		_, err = rf.Write([]byte{'a' + byte(i), '\n'})
+		require.NoError(t, err)
+		clock.Advance(30 * time.Minute)
+		require.NoError(t, rf.Rotate())
+	}
+
+	require.NoError(t, rf.Close())
+
+	// The oldest backup exceeds MaxBackups and the second oldest exceeds MaxAge.
+	assert.Equal(t, []string{
+		"app",
+		"app-2024-01-02T01-30-00.000.gz",
+		"app-2024-01-02T02-00-00.000.gz",
+		"app-2024-01-02T02-30-00.000.gz",
+	}, dirFiles(t, dir))
+	assert.Equal(t, "e\n", readFile(t, filepath.Join(dir, "app-2024-01-02T02-30-00.000.gz")))
+	assert.Equal(t, "c\n", readFile(t, filepath.Join(dir, "app-2024-01-02T01-30-00.000.gz")))
+	assert.Empty(t, readFile(t, filepath.Join(dir, "app")))
+}
+
+func TestRotatingFileConcurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	rf, err := slogkit.NewRotatingFile(filepath.Join(dir, "app.log"), slogkit.RotatingFileConfig{ //nolint:exhaustruct_v5
+		MaxSize: 1024,
+	})
+	require.NoError(t, err)
+
+	logger := slogkit.NewLogger(rf, slogkit.HandlerJSON, nil)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for i := range 100 {
+				logger.Info("message", "i", i)
+			}
+		})
+	}
+
+	wg.Wait()
+	require.NoError(t, rf.Close())
+
+	var lines int
+
+	for _, name := range dirFiles(t, dir) {
+		for l := range strings.Lines(readFile(t, filepath.Join(dir, name))) {
+			assert.True(t, strings.HasPrefix(l, "{") && strings.HasSuffix(l, "}\n"), l)
+
+			lines++
+		}
+	}
+
+	assert.Equal(t, 800, lines)
+}
+
+func TestRotatingFileOpenError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := slogkit.NewRotatingFile(path, slogkit.RotatingFileConfig{}) //nolint:exhaustruct_v5
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = rf.Close() })
+
+	// A directory in place of the file makes opening it fail.
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.Mkdir(path, 0o750))
+	require.Error(t, rf.Reopen())
+
+	_, err = rf.Write([]byte("lost\n"))
+	require.Error(t, err)
+
+	require.NoError(t, os.Remove(path))
+
+	_, err = rf.Write([]byte("after\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "after\n", readFile(t, path))
+
+	require.NoError(t, rf.Close())
+	require.ErrorIs(t, rf.Close(), os.ErrClosed)
+
+	_, err = rf.Write([]byte("closed\n"))
+	require.ErrorIs(t, err, os.ErrClosed)
+}
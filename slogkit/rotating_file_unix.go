@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package slogkit
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifySIGHUP relays SIGHUP to c.
+func notifySIGHUP(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGHUP)
+}
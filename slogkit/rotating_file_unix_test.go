@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package slogkit_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	rf, err := slogkit.NewRotatingFile(path, slogkit.RotatingFileConfig{ReopenOnSIGHUP: true}) //nolint:exhaustruct_v5
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = rf.Close() })
+
+	_, err = rf.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	// Simulate logrotate: move the file and signal the process.
+	require.NoError(t, os.Rename(path, path+".1"))
+
+	proc, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, proc.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+
+		return err == nil
+	}, time.Second, time.Millisecond)
+
+	_, err = rf.Write([]byte("after\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "before\n", readFile(t, path+".1"))
+	assert.Equal(t, "after\n", readFile(t, path))
+}
+
+func TestRotatingFileRenameError(t *testing.T) {
+	dir := t.TempDir()
+
+	// The backup name exceeds the maximum file name length, therefore renaming fails.
+	path := filepath.Join(dir, strings.Repeat("a", 240)+".log")
+
+	rf, err := slogkit.NewRotatingFile(path, slogkit.RotatingFileConfig{}) //nolint:exhaustruct_v5
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = rf.Close() })
+
+	_, err = rf.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	require.ErrorIs(t, rf.Rotate(), syscall.ENAMETOOLONG)
+
+	_, err = rf.Write([]byte("after\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "before\nafter\n", readFile(t, path))
+}
@@ -0,0 +1,362 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Attribute keys added by [SamplingHandler].
+const (
+	// SuppressedKey is the key of the number of records suppressed since the last record
+	// with the same level and message was emitted.
+	SuppressedKey = "suppressed"
+	// RepeatedKey is the key of the number of collapsed duplicate records.
+	RepeatedKey = "repeated"
+)
+
+// Defaults used by [NewSamplingHandler] for unset configuration values.
+const (
+	DefaultSamplingInterval = time.Second
+	DefaultSamplingFirst    = 100
+)
+
+// SamplingConfig configures a [SamplingHandler].
+type SamplingConfig struct {
+	// Interval is the sampling period, [DefaultSamplingInterval] if 0. It is also the period
+	// for emitting repetition records of collapsed duplicates.
+	Interval time.Duration
+	// First is the number of records per level and message passed in each interval,
+	// [DefaultSamplingFirst] if 0.
+	First int
+	// Thereafter is the sampling rate after First records in an interval: one in every
+	// Thereafter records is passed. If 0, all records after First are suppressed.
+	Thereafter int
+	// Exempt is the level at or above which records are never sampled, [slog.LevelError] if nil.
+	Exempt slog.Leveler
+	// Collapse enables collapsing consecutive exact duplicate records (same level, message
+	// and attributes) into a single repetition record, for example "last message repeated
+	// 532 times". Collapsing applies to all levels.
+	Collapse bool
+	// Now returns the current time, [time.Now] if nil. It is useful for testing.
+	Now func() time.Time
+}
+
+// SamplingHandler is an slog Handler that samples records per level and message and optionally
+// collapses exact duplicate records before passing them to another handler. The number of
+// suppressed records is attached with [SuppressedKey] to the next passed record with the same
+// level and message. If there is none within an interval after the last suppressed record,
+// the number is reported in a record with the same level and message and only the
+// [SuppressedKey] attribute, passed to the handler that suppressed it. Handlers derived with
+// [SamplingHandler.WithAttrs] and [SamplingHandler.WithGroup] share the sampling state.
+type SamplingHandler struct {
+	s       *samplingState
+	handler slog.Handler
+}
+
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+type samplingCounter struct {
+	owner      *SamplingHandler // handler of the last sampled record
+	start      time.Time
+	n          uint64
+	suppressed uint64
+}
+
+// suppressedSummary reports the suppressed records of a key not reported in a passed record.
+type suppressedSummary struct {
+	owner *SamplingHandler
+	key   samplingKey
+	n     uint64
+}
+
+// repeatState tracks the last passed record for collapsing duplicates.
+type repeatState struct {
+	owner *SamplingHandler
+	fp    string // fingerprint of the record
+	level slog.Level
+	count uint64 // duplicates since the last repetition record
+	since time.Time
+}
+
+// samplingState is the state shared by a SamplingHandler and all its derived handlers.
+type samplingState struct {
+	cfg SamplingConfig
+
+	mu        sync.Mutex
+	counters  map[samplingKey]*samplingCounter
+	lastSweep time.Time
+	last      *repeatState
+
+	suppressed atomic.Uint64
+}
+
+// NewSamplingHandler creates a [SamplingHandler] that passes sampled records to next using cfg.
+func NewSamplingHandler(next slog.Handler, cfg SamplingConfig) *SamplingHandler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultSamplingInterval
+	}
+
+	if cfg.First <= 0 {
+		cfg.First = DefaultSamplingFirst
+	}
+
+	if cfg.Exempt == nil {
+		cfg.Exempt = slog.LevelError
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	s := &samplingState{ //nolint:exhaustruct_v5 // Zero values are valid.
+		cfg:      cfg,
+		counters: map[samplingKey]*samplingCounter{},
+	}
+
+	return &SamplingHandler{s: s, handler: next}
+}
+
+// Suppressed returns the total number of records suppressed by sampling.
+func (h *SamplingHandler) Suppressed() uint64 {
+	return h.s.suppressed.Load()
+}
+
+// Enabled implements [slog.Handler] by calling the wrapped handler.
+func (h *SamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle implements [slog.Handler].
+func (h *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	now := h.s.cfg.Now()
+
+	var fp string
+	if h.s.cfg.Collapse {
+		fp = fingerprint(r)
+	}
+
+	h.s.mu.Lock()
+
+	if last := h.s.last; h.s.cfg.Collapse && last != nil && last.owner == h && last.fp == fp {
+		last.count++
+
+		if now.Sub(last.since) < h.s.cfg.Interval {
+			h.s.mu.Unlock()
+
+			return nil
+		}
+
+		repeat := *last
+		last.count, last.since = 0, now
+		h.s.mu.Unlock()
+
+		return repeat.emit(ctx, now)
+	}
+
+	repeat := h.s.last
+	h.s.last = nil
+
+	key := samplingKey{level: r.Level, msg: r.Message}
+	summaries := h.s.sweep(now, key)
+	pass, suppressed := h.s.sample(h, now, key)
+	if pass && h.s.cfg.Collapse {
+		h.s.last = &repeatState{owner: h, fp: fp, level: r.Level, count: 0, since: now}
+	}
+
+	h.s.mu.Unlock()
+
+	var errs []error
+
+	if repeat != nil && repeat.count > 0 {
+		errs = append(errs, repeat.emit(ctx, now))
+	}
+
+	for _, sum := range summaries {
+		errs = append(errs, sum.emit(ctx, now))
+	}
+
+	if pass {
+		if suppressed > 0 {
+			r = r.Clone()
+			r.AddAttrs(slog.Uint64(SuppressedKey, suppressed))
+		}
+
+		errs = append(errs, h.handler.Handle(ctx, r))
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements [slog.Handler]. The returned handler shares the sampling state of h.
+func (h *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{s: h.s, handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements [slog.Handler]. The returned handler shares the sampling state of h.
+func (h *SamplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &SamplingHandler{s: h.s, handler: h.handler.WithGroup(name)}
+}
+
+// Flush emits the pending repetition record of collapsed duplicates and the records reporting
+// pending suppressed records, if any.
+func (h *SamplingHandler) Flush(ctx context.Context) error {
+	now := h.s.cfg.Now()
+
+	h.s.mu.Lock()
+
+	var repeat *repeatState
+	if last := h.s.last; last != nil && last.count > 0 {
+		rs := *last
+		repeat = &rs
+		last.count, last.since = 0, now
+	}
+
+	var summaries []suppressedSummary
+
+	for k, c := range h.s.counters {
+		if c.suppressed > 0 {
+			summaries = append(summaries, suppressedSummary{owner: c.owner, key: k, n: c.suppressed})
+			c.suppressed = 0
+		}
+	}
+
+	h.s.mu.Unlock()
+
+	sortSummaries(summaries)
+
+	var errs []error
+
+	if repeat != nil {
+		errs = append(errs, repeat.emit(ctx, now))
+	}
+
+	for _, sum := range summaries {
+		errs = append(errs, sum.emit(ctx, now))
+	}
+
+	return errors.Join(errs...)
+}
+
+// sample decides whether a record of h is passed and returns the number of records suppressed
+// since the last passed record with the same key, must be called with the lock held.
+func (s *samplingState) sample(h *SamplingHandler, now time.Time, key samplingKey) (bool, uint64) {
+	if key.level >= s.cfg.Exempt.Level() {
+		return true, 0
+	}
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &samplingCounter{owner: h, start: now, n: 0, suppressed: 0}
+		s.counters[key] = c
+	}
+
+	c.owner = h
+
+	if now.Sub(c.start) >= s.cfg.Interval {
+		c.start, c.n = now, 0
+	}
+
+	c.n++
+
+	first, thereafter := uint64(s.cfg.First), uint64(max(s.cfg.Thereafter, 0)) //nolint:gosec // Not negative.
+	if c.n <= first || (thereafter > 0 && (c.n-first)%thereafter == 0) {
+		suppressed := c.suppressed
+		c.suppressed = 0
+
+		return true, suppressed
+	}
+
+	c.suppressed++
+	s.suppressed.Add(1)
+
+	return false, 0
+}
+
+// sweep removes expired counters once per interval and returns the summaries of their pending
+// suppressed records, except for the counter of current, whose pending suppressed records are
+// attached to the record being sampled. It must be called with the lock held.
+func (s *samplingState) sweep(now time.Time, current samplingKey) []suppressedSummary {
+	if now.Sub(s.lastSweep) < s.cfg.Interval {
+		return nil
+	}
+
+	s.lastSweep = now
+
+	var summaries []suppressedSummary
+
+	for k, c := range s.counters {
+		if k == current || now.Sub(c.start) < s.cfg.Interval {
+			continue
+		}
+
+		if c.suppressed > 0 {
+			summaries = append(summaries, suppressedSummary{owner: c.owner, key: k, n: c.suppressed})
+		}
+
+		delete(s.counters, k)
+	}
+
+	sortSummaries(summaries)
+
+	return summaries
+}
+
+// sortSummaries sorts summaries by level and message for a predictable output.
+func sortSummaries(summaries []suppressedSummary) {
+	slices.SortFunc(summaries, func(a, b suppressedSummary) int {
+		return cmp.Or(cmp.Compare(a.key.level, b.key.level), strings.Compare(a.key.msg, b.key.msg))
+	})
+}
+
+// emit passes a record reporting the suppressed records to the owner handler.
+func (s suppressedSummary) emit(ctx context.Context, now time.Time) error {
+	rec := slog.NewRecord(now, s.key.level, s.key.msg, 0)
+	rec.AddAttrs(slog.Uint64(SuppressedKey, s.n))
+
+	return s.owner.handler.Handle(ctx, rec) //nolint:wrapcheck // Transparent wrapper.
+}
+
+// emit passes a repetition record for the collapsed duplicates to the owner handler.
+func (r *repeatState) emit(ctx context.Context, now time.Time) error {
+	rec := slog.NewRecord(now, r.level, fmt.Sprintf("last message repeated %d times", r.count), 0)
+	rec.AddAttrs(slog.Uint64(RepeatedKey, r.count))
+
+	return r.owner.handler.Handle(ctx, rec) //nolint:wrapcheck // Transparent wrapper.
+}
+
+// fingerprint returns a string that identifies the level, message and attributes of r.
+func fingerprint(r slog.Record) string {
+	var b strings.Builder
+
+	b.WriteString(strconv.Itoa(int(r.Level)))
+	b.WriteByte(0)
+	b.WriteString(r.Message)
+
+	r.Attrs(func(a slog.Attr) bool {
+		b.WriteByte(0)
+		b.WriteString(a.String())
+
+		return true
+	})
+
+	return b.String()
+}
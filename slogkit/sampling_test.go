@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noTimeText creates a text handler without the time attribute for predictable output.
+func noTimeText(buf *bytes.Buffer) slog.Handler {
+	return slog.NewTextHandler(buf, &slog.HandlerOptions{ //nolint:exhaustruct_v5
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	})
+}
+
+func bufLines(buf *bytes.Buffer) []string {
+	defer buf.Reset()
+
+	var out []string
+	for l := range strings.Lines(buf.String()) {
+		out = append(out, strings.TrimSuffix(l, "\n"))
+	}
+
+	return out
+}
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)} //nolint:exhaustruct_v5
+	h := slogkit.NewSamplingHandler(noTimeText(&buf), slogkit.SamplingConfig{
+		Interval:   time.Second,
+		First:      2,
+		Thereafter: 3,
+		Exempt:     nil,
+		Collapse:   false,
+		Now:        clock.Now,
+	})
+	logger := slog.New(h)
+
+	for i := range 10 {
+		logger.Info("boom", "i", i)
+		logger.Warn("other")
+		logger.Error("failed", "i", i)
+	}
+
+	lines := bufLines(&buf)
+	assert.Equal(t, []string{
+		"level=INFO msg=boom i=0",
+		"level=INFO msg=boom i=1",
+		"level=INFO msg=boom i=4 suppressed=2",
+		"level=INFO msg=boom i=7 suppressed=2",
+	}, filterLines(lines, "msg=boom"))
+	assert.Len(t, filterLines(lines, "msg=other"), 4)
+	assert.Len(t, filterLines(lines, "msg=failed"), 10)
+	assert.Equal(t, uint64(6+6), h.Suppressed())
+
+	// A new interval passes the first records again with the pending suppressed count,
+	// which is reported in a separate record for other messages.
+	clock.Advance(time.Second)
+	logger.Info("boom", "i", 10)
+	logger.Info("boom", "i", 11)
+	assert.Equal(t, []string{
+		"level=WARN msg=other suppressed=2",
+		"level=INFO msg=boom i=10 suppressed=2",
+		"level=INFO msg=boom i=11",
+	}, bufLines(&buf))
+}
+
+func TestSamplingHandlerSummary(t *testing.T) {
+	var buf bytes.Buffer
+
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)} //nolint:exhaustruct_v5
+	h := slogkit.NewSamplingHandler(noTimeText(&buf), slogkit.SamplingConfig{
+		Interval:   time.Second,
+		First:      1,
+		Thereafter: 0,
+		Exempt:     nil,
+		Collapse:   false,
+		Now:        clock.Now,
+	})
+	logger := slog.New(h)
+
+	// Messages that never recur have their suppressed records reported once expired.
+	for i := range 3 {
+		for range 3 {
+			logger.With("id", i).Info("user " + strconv.Itoa(i))
+		}
+	}
+
+	assert.Len(t, bufLines(&buf), 3)
+
+	clock.Advance(time.Second)
+	logger.Info("tick")
+	assert.Equal(t, []string{
+		"level=INFO msg=\"user 0\" id=0 suppressed=2",
+		"level=INFO msg=\"user 1\" id=1 suppressed=2",
+		"level=INFO msg=\"user 2\" id=2 suppressed=2",
+		"level=INFO msg=tick",
+	}, bufLines(&buf))
+
+	// Reported and expired counters are removed.
+	clock.Advance(time.Second)
+	logger.Info("tick")
+	require.NoError(t, h.Flush(t.Context()))
+	assert.Equal(t, []string{"level=INFO msg=tick"}, bufLines(&buf))
+
+	// Flush reports the pending suppressed records.
+	logger.Info("tick")
+	logger.Info("tick")
+	require.NoError(t, h.Flush(t.Context()))
+	require.NoError(t, h.Flush(t.Context()))
+	assert.Equal(t, []string{"level=INFO msg=tick suppressed=2"}, bufLines(&buf))
+}
+
+func TestSamplingHandlerExempt(t *testing.T) {
+	var buf bytes.Buffer
+
+	h := slogkit.NewSamplingHandler(noTimeText(&buf), slogkit.SamplingConfig{
+		Interval:   time.Hour,
+		First:      1,
+		Thereafter: 0,
+		Exempt:     slogkit.LevelFatal,
+		Collapse:   false,
+		Now:        nil,
+	})
+	logger := slog.New(h)
+
+	for range 3 {
+		logger.Error("failed")
+		logger.Log(t.Context(), slogkit.LevelFatal, "fatal")
+	}
+
+	assert.Equal(t, []string{
+		"level=ERROR msg=failed",
+		"level=ERROR+4 msg=fatal",
+		"level=ERROR+4 msg=fatal",
+		"level=ERROR+4 msg=fatal",
+	}, bufLines(&buf))
+}
+
+func TestSamplingHandlerCollapse(t *testing.T) {
+	var buf bytes.Buffer
+
+	clock := &fakeClock{now: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)} //nolint:exhaustruct_v5
+	h := slogkit.NewSamplingHandler(noTimeText(&buf), slogkit.SamplingConfig{
+		Interval:   time.Minute,
+		First:      1000,
+		Thereafter: 0,
+		Exempt:     nil,
+		Collapse:   true,
+		Now:        clock.Now,
+	})
+	logger := slog.New(h).With("dep", "db")
+
+	for range 5 {
+		logger.Error("connection refused", "port", 5432)
+	}
+
+	logger.Error("connection refused", "port", 5433)
+	logger.Error("connection refused", "port", 5433)
+
+	for range 3 {
+		clock.Advance(40 * time.Second)
+		logger.Error("connection refused", "port", 5433)
+	}
+
+	logger.Info("recovered")
+	logger.Info("recovered")
+	require.NoError(t, h.Flush(t.Context()))
+	require.NoError(t, h.Flush(t.Context()))
+
+	assert.Equal(t, []string{
+		"level=ERROR msg=\"connection refused\" dep=db port=5432",
+		"level=ERROR msg=\"last message repeated 4 times\" dep=db repeated=4",
+		"level=ERROR msg=\"connection refused\" dep=db port=5433",
+		"level=ERROR msg=\"last message repeated 3 times\" dep=db repeated=3",
+		"level=ERROR msg=\"last message repeated 1 times\" dep=db repeated=1",
+		"level=INFO msg=recovered dep=db",
+		"level=INFO msg=\"last message repeated 1 times\" dep=db repeated=1",
+	}, bufLines(&buf))
+}
+
+func filterLines(lines []string, substr string) []string {
+	var out []string
+
+	for _, l := range lines {
+		if strings.Contains(l, substr) {
+			out = append(out, l)
+		}
+	}
+
+	return out
+}
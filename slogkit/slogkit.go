@@ -7,11 +7,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"os"
 
 	"github.com/lmittmann/tint"
-	"github.com/mattn/go-colorable"
-	"github.com/mattn/go-isatty"
 )
 
 // Handler represents a supported slogkit handler.
@@ -25,8 +22,32 @@ const (
 	HandlerJSON
 	// HandlerTint is an slog Handler which outputs colorized logs in key=value format.
 	HandlerTint
-	// HandlerAuto uses HandlerTint if the output writer is a terminal or HandlerText otherwise.
+	// HandlerAuto selects a handler for the output writer using an [AutoPolicy], by default
+	// [DefaultAutoPolicy]: HandlerJournal with a process-wide [JournalWriter] for the stream
+	// connected to systemd-journald, HandlerTint for terminals and HandlerText otherwise,
+	// following the NO_COLOR, FORCE_COLOR and CLICOLOR conventions and using HandlerJSON in
+	// Kubernetes.
 	HandlerAuto
+	// HandlerGCP is an slog JSONHandler which outputs logs using the Google Cloud Logging
+	// structured logging fields: time, severity, message, logging.googleapis.com/sourceLocation
+	// and the trace fields from the [TraceIDKey], [SpanIDKey] and [TraceSampledKey] attributes.
+	HandlerGCP
+	// HandlerECS is an slog JSONHandler which outputs logs using the Elastic Common Schema
+	// fields: @timestamp, log.level, message, ecs.version, log.origin, error.* from an "error"
+	// or "err" attribute with an error value (with error.stack_trace for errors created with
+	// [Errorf] or [WithStack]), and trace.id and span.id from the [TraceIDKey] and [SpanIDKey]
+	// attributes.
+	HandlerECS
+	// HandlerSyslog outputs logs as RFC 5424 syslog messages (or RFC 3164 with
+	// [WithSyslogRFC3164]), one per write and with the attributes as structured data elements.
+	// Use it with a [SyslogWriter] to send the messages to a syslog daemon.
+	HandlerSyslog
+	// HandlerJournal outputs logs as systemd journal entries using the native journald protocol,
+	// one per write and with the attributes as uppercase fields (groups joined with "_"), plus
+	// MESSAGE, PRIORITY, SYSLOG_IDENTIFIER and CODE_FILE, CODE_LINE and CODE_FUNC for sources.
+	// Attributes with the same names as these fields are prefixed with "ATTR_".
+	// Use it with a [JournalWriter] to send the entries to systemd-journald.
+	HandlerJournal
 )
 
 // String returns a name for the slogkit handler.
@@ -40,6 +61,14 @@ func (h Handler) String() string {
 		return "tint"
 	case HandlerAuto:
 		return "auto"
+	case HandlerGCP:
+		return "gcp"
+	case HandlerECS:
+		return "ecs"
+	case HandlerSyslog:
+		return "syslog"
+	case HandlerJournal:
+		return "journal"
 	default:
 		return fmt.Sprintf("Handler(%d)", h)
 	}
@@ -63,6 +92,14 @@ func (h *Handler) UnmarshalText(b []byte) error {
 		*h = HandlerTint
 	case HandlerAuto.String():
 		*h = HandlerAuto
+	case HandlerGCP.String():
+		*h = HandlerGCP
+	case HandlerECS.String():
+		*h = HandlerECS
+	case HandlerSyslog.String():
+		*h = HandlerSyslog
+	case HandlerJournal.String():
+		*h = HandlerJournal
 	default:
 		return fmt.Errorf("%q: %w", str, ErrUnknownHandlerName)
 	}
@@ -74,39 +111,74 @@ func (h *Handler) UnmarshalText(b []byte) error {
 // and the specified leveler implementation (for minimum logging level). This function also renames
 // the built-in [slog.TimeKey] attribute to "ts" for shorter log lines.
 func NewLogger(writer io.Writer, handler Handler, leveler slog.Leveler) *slog.Logger {
-	opts := &slog.HandlerOptions{
-		AddSource: false,
-		Level:     leveler,
-		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
-			if a.Key == slog.TimeKey {
-				a.Key = "ts"
-			}
-
-			return a
-		},
+	return NewLoggerWithOptions(writer, handler, leveler)
+}
+
+// NewLoggerWithOptions is like [NewLogger] but accepts options to customize the logger.
+// Without options, it behaves exactly like [NewLogger]. It returns nil if handler is unknown.
+// If leveler is a [*ComponentLevels], per-component levels are applied (see [NewComponentHandler]).
+// Context attributes are added to records logged with a context (see [NewContextHandler]).
+func NewLoggerWithOptions(
+	writer io.Writer,
+	handler Handler,
+	leveler slog.Leveler,
+	opts ...Option,
+) *slog.Logger {
+	hdl := newLoggerHandler(writer, handler, leveler, opts)
+	if hdl == nil {
+		return nil
 	}
 
-	if handler == HandlerAuto {
-		handler = HandlerText
+	return slog.New(hdl)
+}
+
+// newLoggerHandler creates the slog Handler of a logger, including per-component levels and
+// context attributes, or nil if handler is unknown.
+func newLoggerHandler(writer io.Writer, handler Handler, leveler slog.Leveler, opts []Option) slog.Handler {
+	hdl := newHandler(writer, handler, leveler, newOptions(opts...))
+	if hdl == nil {
+		return nil
+	}
+
+	if cl, ok := leveler.(*ComponentLevels); ok {
+		hdl = NewComponentHandler(hdl, cl)
+	}
+
+	return NewContextHandler(hdl)
+}
+
+// newHandler creates the slog Handler for the specified slogkit handler or nil if it is unknown.
+func newHandler(writer io.Writer, handler Handler, leveler slog.Leveler, o *options) slog.Handler {
+	opts := o.handlerOptions(leveler)
 
-		if f, ok := writer.(*os.File); ok && isatty.IsTerminal(f.Fd()) {
-			handler = HandlerTint
-			writer = colorable.NewColorable(f)
-		}
+	if handler == HandlerAuto {
+		handler, writer = o.autoHandler(writer)
 	}
 
 	switch handler {
 	case HandlerText:
-		return slog.New(slog.NewTextHandler(writer, opts))
+		opts.ReplaceAttr = levelReplaceAttr(opts.ReplaceAttr)
+
+		return slog.NewTextHandler(writer, opts)
 	case HandlerJSON:
-		return slog.New(slog.NewJSONHandler(writer, opts))
+		opts.ReplaceAttr = levelReplaceAttr(opts.ReplaceAttr)
+
+		return slog.NewJSONHandler(writer, opts)
 	case HandlerTint:
-		return slog.New(
-			tint.NewTextHandler(writer, &tint.Options{ //nolint:exhaustruct_v5 // Use defaults.
-				AddSource: opts.AddSource,
-				Level:     opts.Level,
-			}),
-		)
+		return tint.NewTextHandler(writer, &tint.Options{ //nolint:exhaustruct_v5 // Use defaults.
+			AddSource:   opts.AddSource,
+			Level:       opts.Level,
+			ReplaceAttr: tintReplaceAttr(opts.ReplaceAttr, o.tintTheme),
+			NoColor:     o.noColor,
+		})
+	case HandlerGCP:
+		return newGCPHandler(writer, leveler, o)
+	case HandlerECS:
+		return newECSHandler(writer, leveler, o)
+	case HandlerSyslog:
+		return newSyslogHandler(writer, leveler, o)
+	case HandlerJournal:
+		return newJournalHandler(writer, leveler, o)
 	case HandlerAuto:
 	}
 
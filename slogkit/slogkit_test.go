@@ -8,7 +8,9 @@ import (
 	"context"
 	"log/slog"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hhromic/go-toolkit/slogkit"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +43,26 @@ func TestHandlerString(t *testing.T) {
 			handler: slogkit.HandlerAuto,
 			want:    "auto",
 		},
+		{
+			name:    "HandlerGCP",
+			handler: slogkit.HandlerGCP,
+			want:    "gcp",
+		},
+		{
+			name:    "HandlerECS",
+			handler: slogkit.HandlerECS,
+			want:    "ecs",
+		},
+		{
+			name:    "HandlerSyslog",
+			handler: slogkit.HandlerSyslog,
+			want:    "syslog",
+		},
+		{
+			name:    "HandlerJournal",
+			handler: slogkit.HandlerJournal,
+			want:    "journal",
+		},
 		{
 			name:    "UnknownHandler",
 			handler: -1,
@@ -86,6 +108,30 @@ func TestHandlerMarshalText(t *testing.T) {
 			want:    []byte("auto"),
 			wantErr: nil,
 		},
+		{
+			name:    "HandlerGCP",
+			handler: slogkit.HandlerGCP,
+			want:    []byte("gcp"),
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerECS",
+			handler: slogkit.HandlerECS,
+			want:    []byte("ecs"),
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerSyslog",
+			handler: slogkit.HandlerSyslog,
+			want:    []byte("syslog"),
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerJournal",
+			handler: slogkit.HandlerJournal,
+			want:    []byte("journal"),
+			wantErr: nil,
+		},
 		{
 			name:    "UnknownHandler",
 			handler: -1,
@@ -137,6 +183,30 @@ func TestHandlerUnmarshalText(t *testing.T) {
 			want:    slogkit.HandlerAuto,
 			wantErr: nil,
 		},
+		{
+			name:    "HandlerGCP",
+			b:       []byte("gcp"),
+			want:    slogkit.HandlerGCP,
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerECS",
+			b:       []byte("ecs"),
+			want:    slogkit.HandlerECS,
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerSyslog",
+			b:       []byte("syslog"),
+			want:    slogkit.HandlerSyslog,
+			wantErr: nil,
+		},
+		{
+			name:    "HandlerJournal",
+			b:       []byte("journal"),
+			want:    slogkit.HandlerJournal,
+			wantErr: nil,
+		},
 		{
 			name:    "InvalidHandler",
 			b:       []byte("foobar"),
@@ -239,3 +309,102 @@ func TestNewLogger(t *testing.T) {
 		assert.Nil(t, l)
 	})
 }
+
+func TestNewLoggerWithOptions(t *testing.T) {
+	dropSecret := slogkit.WithReplaceAttr(func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == "secret" {
+			return slog.Attr{}
+		}
+
+		return a
+	})
+	upperValues := slogkit.WithReplaceAttr(func(_ []string, a slog.Attr) slog.Attr {
+		if a.Value.Kind() == slog.KindString && a.Key != slog.MessageKey {
+			a.Value = slog.StringValue(strings.ToUpper(a.Value.String()))
+		}
+
+		return a
+	})
+
+	testCases := []struct {
+		name    string
+		handler slogkit.Handler
+		opts    []slogkit.Option
+		args    []any
+		want    *regexp.Regexp
+	}{
+		{
+			name:    "HandlerText-NoOptions",
+			handler: slogkit.HandlerText,
+			opts:    nil,
+			args:    []any{"key", "val"},
+			want:    regexp.MustCompile(`^ts=\S+ level=INFO msg=message key=val\n$`),
+		},
+		{
+			name:    "HandlerText-StandardTimeKey",
+			handler: slogkit.HandlerText,
+			opts:    []slogkit.Option{slogkit.WithTimeKey(slog.TimeKey)},
+			args:    []any{"key", "val"},
+			want:    regexp.MustCompile(`^time=\S+ level=INFO msg=message key=val\n$`),
+		},
+		{
+			name:    "HandlerText-Source",
+			handler: slogkit.HandlerText,
+			opts:    []slogkit.Option{slogkit.WithSource(true)},
+			args:    []any{"key", "val"},
+			want:    regexp.MustCompile(`^ts=\S+ level=INFO source=\S+/slogkit_test\.go:\d+ msg=message key=val\n$`),
+		},
+		{
+			name:    "HandlerText-LayoutUTC",
+			handler: slogkit.HandlerText,
+			opts:    []slogkit.Option{slogkit.WithTimeLayout(time.RFC3339Nano), slogkit.WithTimeUTC()},
+			args:    []any{"key", "val"},
+			want:    regexp.MustCompile(`^ts=\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?Z level=INFO msg=message key=val\n$`),
+		},
+		{
+			name:    "HandlerJSON-UnixMilli",
+			handler: slogkit.HandlerJSON,
+			opts:    []slogkit.Option{slogkit.WithTimeUnixMilli(), slogkit.WithTimeKey("@ts")},
+			args:    []any{"key", "val"},
+			want:    regexp.MustCompile(`^{"@ts":\d{13},"level":"INFO","msg":"message","key":"val"}\n$`),
+		},
+		{
+			name:    "HandlerJSON-ReplaceAttrChain",
+			handler: slogkit.HandlerJSON,
+			opts:    []slogkit.Option{dropSecret, upperValues},
+			args:    []any{"key", "val", "secret", "hunter2"},
+			want:    regexp.MustCompile(`^{"ts":".+","level":"INFO","msg":"message","key":"VAL"}\n$`),
+		},
+		{
+			name:    "HandlerTint-ReplaceAttrChain",
+			handler: slogkit.HandlerTint,
+			opts:    []slogkit.Option{dropSecret, upperValues},
+			args:    []any{"key", "val", "secret", "hunter2"},
+			want: regexp.MustCompile(
+				`^\x1b\[2m.+\x1b\[0m \x1b\[92mINF\x1b\[0m message \x1b\[2mkey=\x1b\[0mVAL\n$`,
+			),
+		},
+		{
+			name:    "HandlerTint-LayoutSource",
+			handler: slogkit.HandlerTint,
+			opts:    []slogkit.Option{slogkit.WithTimeLayout(time.Kitchen), slogkit.WithSource(true)},
+			args:    []any{"key", "val"},
+			want: regexp.MustCompile(
+				`^\x1b\[2m\d+:\d\d[AP]M\x1b\[0m \x1b\[92mINF\x1b\[0m \x1b\[2mslogkit/slogkit_test\.go:\d+\x1b\[0m ` +
+					`message \x1b\[2mkey=\x1b\[0mval\n$`,
+			),
+		},
+	}
+
+	for _, tCase := range testCases {
+		t.Run(tCase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			l := slogkit.NewLoggerWithOptions(&buf, tCase.handler, slog.LevelDebug, tCase.opts...)
+			require.NotNil(t, l)
+
+			l.Info("message", tCase.args...)
+			assert.Regexp(t, tCase.want, buf.String())
+		})
+	}
+}
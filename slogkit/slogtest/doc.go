@@ -0,0 +1,6 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+// Package slogtest provides an in-memory recording [log/slog] handler and assertion helpers
+// for testing code that logs.
+package slogtest
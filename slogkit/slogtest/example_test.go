@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogtest_test
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/hhromic/go-toolkit/slogkit/slogtest"
+)
+
+func ExampleHandler() {
+	h := slogtest.NewHandler(slog.LevelInfo)
+	logger := slog.New(h)
+
+	logger.WithGroup("http").Info("request handled", "status", 200, "path", "/")
+
+	for _, rec := range h.Records() {
+		fmt.Println(rec)
+	}
+	// Output:
+	// INFO request handled http.path=/ http.status=200
+}
+
+func ExampleHandler_AssertLogged() {
+	checkDisk := func(logger *slog.Logger) {
+		logger.Warn("disk almost full", "free", "5%")
+	}
+
+	_ = func(t *testing.T) { // In a test function.
+		h := slogtest.NewHandler(nil)
+		checkDisk(slog.New(h))
+
+		h.AssertLogged(t, slog.LevelWarn, "disk almost full", "free", "5%")
+	}
+}
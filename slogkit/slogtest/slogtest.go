@@ -0,0 +1,345 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Record is a log record captured by a [Handler].
+type Record struct {
+	// Time is the time of the record, zero if it had no time.
+	Time time.Time
+	// Level is the level of the record.
+	Level slog.Level
+	// Message is the message of the record.
+	Message string
+	// Attrs are the resolved attributes of the record and its handler, with their keys qualified
+	// with their groups joined by dots (for example "http.status"). Empty groups are omitted and
+	// attributes of groups with an empty key are inlined.
+	Attrs map[string]slog.Value
+	// Groups are the groups opened with WithGroup on the handler of the record.
+	Groups []string
+	// PC is the program counter of the log call, zero if unknown.
+	PC uintptr
+}
+
+// Attr returns the value of the attribute with the qualified key.
+func (r Record) Attr(key string) (slog.Value, bool) {
+	v, ok := r.Attrs[key]
+
+	return v, ok
+}
+
+// Matches reports whether the record has the level and message and contains the attributes in
+// args, which are interpreted as in [slog.Logger.Log]. Attributes in groups are matched by
+// their qualified keys.
+func (r Record) Matches(level slog.Level, msg string, args ...any) bool {
+	if r.Level != level || r.Message != msg {
+		return false
+	}
+
+	want := map[string]slog.Value{}
+	flatten(want, nil, slog.Group("", args...))
+
+	for key, v := range want {
+		if got, ok := r.Attrs[key]; !ok || !got.Equal(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns the record in a text format for failure messages.
+func (r Record) String() string {
+	var b strings.Builder
+
+	b.WriteString(r.Level.String() + " " + r.Message)
+
+	keys := make([]string, 0, len(r.Attrs))
+	for key := range r.Attrs {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		b.WriteString(" " + key + "=" + r.Attrs[key].String())
+	}
+
+	return b.String()
+}
+
+// Handler is an [slog.Handler] that records the log records in memory.
+// Handlers derived with WithAttrs and WithGroup share the records.
+// It is safe for concurrent use.
+type Handler struct {
+	rec     *recorder
+	leveler slog.Leveler
+	attrs   map[string]slog.Value
+	groups  []string
+}
+
+// recorder holds the records shared by a handler and its derived handlers.
+type recorder struct {
+	mu      sync.Mutex
+	records []Record
+	changed chan struct{} // closed and replaced when a record is added
+}
+
+// NewHandler creates a recording [Handler] that records the records of at least the level of
+// leveler, or of all levels if leveler is nil.
+func NewHandler(leveler slog.Leveler) *Handler {
+	return &Handler{
+		rec:     &recorder{mu: sync.Mutex{}, records: nil, changed: make(chan struct{})},
+		leveler: leveler,
+		attrs:   map[string]slog.Value{},
+		groups:  nil,
+	}
+}
+
+// Enabled implements [slog.Handler].
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.leveler == nil || level >= h.leveler.Level()
+}
+
+// Handle implements [slog.Handler].
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	rec := Record{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   make(map[string]slog.Value, len(h.attrs)+r.NumAttrs()),
+		Groups:  h.groups,
+		PC:      r.PC,
+	}
+
+	for key, v := range h.attrs {
+		rec.Attrs[key] = v
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		flatten(rec.Attrs, h.groups, a)
+
+		return true
+	})
+
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+
+	h.rec.records = append(h.rec.records, rec)
+	close(h.rec.changed)
+	h.rec.changed = make(chan struct{})
+
+	return nil
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = make(map[string]slog.Value, len(h.attrs)+len(attrs))
+
+	for key, v := range h.attrs {
+		h2.attrs[key] = v
+	}
+
+	for _, a := range attrs {
+		flatten(h2.attrs, h.groups, a)
+	}
+
+	return &h2
+}
+
+// WithGroup implements [slog.Handler].
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
+}
+
+// Records returns a copy of the recorded records in order.
+func (h *Handler) Records() []Record {
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+
+	return slices.Clone(h.rec.records)
+}
+
+// Len returns the number of recorded records.
+func (h *Handler) Len() int {
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+
+	return len(h.rec.records)
+}
+
+// Reset discards the recorded records.
+func (h *Handler) Reset() {
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+
+	h.rec.records = nil
+}
+
+// Find returns the first recorded record for which match returns true.
+func (h *Handler) Find(match func(Record) bool) (Record, bool) {
+	h.rec.mu.Lock()
+	defer h.rec.mu.Unlock()
+
+	if i := slices.IndexFunc(h.rec.records, match); i >= 0 {
+		return h.rec.records[i], true
+	}
+
+	return Record{}, false //nolint:exhaustruct_v5 // Zero record.
+}
+
+// WaitFor waits until a record for which match returns true is recorded, including records
+// recorded before the call, and returns it. It returns an error if ctx is done first.
+func (h *Handler) WaitFor(ctx context.Context, match func(Record) bool) (Record, error) {
+	for {
+		h.rec.mu.Lock()
+		i := slices.IndexFunc(h.rec.records, match)
+		rec, changed := Record{}, h.rec.changed //nolint:exhaustruct_v5 // Zero record.
+
+		if i >= 0 {
+			rec = h.rec.records[i]
+		}
+		h.rec.mu.Unlock()
+
+		if i >= 0 {
+			return rec, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return Record{}, fmt.Errorf("wait for record: %w", ctx.Err()) //nolint:exhaustruct_v5 // Zero record.
+		}
+	}
+}
+
+// AssertLogged checks that a record matching level, msg and the attributes in args (see
+// [Record.Matches]) was recorded. Otherwise, it reports an error with the recorded records.
+// It returns whether the assertion succeeded.
+func (h *Handler) AssertLogged(t testing.TB, level slog.Level, msg string, args ...any) bool {
+	t.Helper()
+
+	if _, ok := h.Find(matcher(level, msg, args)); ok {
+		return true
+	}
+
+	t.Errorf("no record matches %s\n%s", describe(level, msg, args), h.dump())
+
+	return false
+}
+
+// AssertNotLogged checks that no record matching level, msg and the attributes in args (see
+// [Record.Matches]) was recorded. Otherwise, it reports an error with the matching record.
+// It returns whether the assertion succeeded.
+func (h *Handler) AssertNotLogged(t testing.TB, level slog.Level, msg string, args ...any) bool {
+	t.Helper()
+
+	if rec, ok := h.Find(matcher(level, msg, args)); ok {
+		t.Errorf("unexpected record matches %s\n  %s", describe(level, msg, args), rec)
+
+		return false
+	}
+
+	return true
+}
+
+// WaitLogged waits up to timeout until a record matching level, msg and the attributes in args
+// (see [Record.Matches]) is recorded and returns it. Otherwise, it stops the test with the
+// recorded records. It is useful for concurrent code.
+func (h *Handler) WaitLogged(
+	t testing.TB,
+	timeout time.Duration,
+	level slog.Level,
+	msg string,
+	args ...any,
+) Record {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	rec, err := h.WaitFor(ctx, matcher(level, msg, args))
+	if err != nil {
+		t.Fatalf("no record matches %s after %v\n%s", describe(level, msg, args), timeout, h.dump())
+	}
+
+	return rec
+}
+
+// dump returns the recorded records for failure messages.
+func (h *Handler) dump() string {
+	records := h.Records()
+	if len(records) == 0 {
+		return "no records recorded"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("recorded records:")
+
+	for _, rec := range records {
+		b.WriteString("\n  " + rec.String())
+	}
+
+	return b.String()
+}
+
+func matcher(level slog.Level, msg string, args []any) func(Record) bool {
+	return func(r Record) bool {
+		return r.Matches(level, msg, args...)
+	}
+}
+
+func describe(level slog.Level, msg string, args []any) string {
+	want := Record{ //nolint:exhaustruct_v5 // Only for formatting.
+		Level:   level,
+		Message: msg,
+		Attrs:   map[string]slog.Value{},
+	}
+	flatten(want.Attrs, nil, slog.Group("", args...))
+
+	return want.String()
+}
+
+// flatten adds the resolved attribute a to attrs with its key qualified with groups,
+// following the [slog.Handler] rules for empty attributes and groups.
+func flatten(attrs map[string]slog.Value, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		inner := groups
+		if a.Key != "" {
+			inner = append(slices.Clip(groups), a.Key)
+		}
+
+		for _, ga := range a.Value.Group() {
+			flatten(attrs, inner, ga)
+		}
+
+		return
+	}
+
+	attrs[strings.Join(append(slices.Clip(groups), a.Key), ".")] = a.Value
+}
@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogtest_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	stdslogtest "testing/slogtest"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/hhromic/go-toolkit/slogkit/slogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT is a [testing.TB] that records failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+
+	errors []string
+	fatal  bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.Errorf(format, args...)
+	f.fatal = true
+}
+
+func TestHandler(t *testing.T) {
+	h := slogtest.NewHandler(slog.LevelInfo)
+	logger := slog.New(h)
+
+	logger.Debug("debug")
+	logger.With("app", "test").WithGroup("http").Info("request",
+		"status", 200, slog.Group("req", "path", "/"), slog.Group("empty"), slog.Group("", "inline", true))
+
+	records := h.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, slog.LevelInfo, records[0].Level)
+	assert.Equal(t, "request", records[0].Message)
+	assert.Equal(t, []string{"http"}, records[0].Groups)
+	assert.Equal(t, map[string]slog.Value{
+		"app":           slog.StringValue("test"),
+		"http.status":   slog.Int64Value(200),
+		"http.req.path": slog.StringValue("/"),
+		"http.inline":   slog.BoolValue(true),
+	}, records[0].Attrs)
+	assert.NotZero(t, records[0].PC)
+
+	v, ok := records[0].Attr("http.status")
+	assert.True(t, ok)
+	assert.Equal(t, int64(200), v.Int64())
+
+	assert.Equal(t, 1, h.Len())
+	h.Reset()
+	assert.Zero(t, h.Len())
+}
+
+func TestRecordMatches(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	slog.New(h).Debug("message", "key", "val", "n", 3, slog.Group("g", "k", 1.5))
+
+	rec := h.Records()[0]
+	assert.True(t, rec.Matches(slog.LevelDebug, "message"))
+	assert.True(t, rec.Matches(slog.LevelDebug, "message", "n", 3))
+	assert.True(t, rec.Matches(slog.LevelDebug, "message", slog.Group("g", "k", 1.5), "key", "val"))
+	assert.True(t, rec.Matches(slog.LevelDebug, "message", "g.k", 1.5))
+	assert.False(t, rec.Matches(slog.LevelInfo, "message"))
+	assert.False(t, rec.Matches(slog.LevelDebug, "other"))
+	assert.False(t, rec.Matches(slog.LevelDebug, "message", "n", 4))
+	assert.False(t, rec.Matches(slog.LevelDebug, "message", "missing", "val"))
+	assert.Equal(t, `DEBUG message g.k=1.5 key=val n=3`, rec.String())
+}
+
+func TestAssertLogged(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	slog.New(h).Warn("disk full", "free", 0)
+
+	assert.True(t, h.AssertLogged(t, slog.LevelWarn, "disk full", "free", 0))
+	assert.True(t, h.AssertNotLogged(t, slog.LevelError, "disk full"))
+
+	ft := &fakeT{TB: t, errors: nil, fatal: false}
+	assert.False(t, h.AssertLogged(ft, slog.LevelWarn, "disk full", "free", 1))
+	assert.False(t, h.AssertNotLogged(ft, slog.LevelWarn, "disk full"))
+	assert.Equal(t, []string{
+		"no record matches WARN disk full free=1\nrecorded records:\n  WARN disk full free=0",
+		"unexpected record matches WARN disk full\n  WARN disk full free=0",
+	}, ft.errors)
+}
+
+func TestWaitLogged(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	logger := slog.New(h)
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for i := range 5 {
+			time.Sleep(time.Millisecond)
+			logger.Info("tick", "i", i)
+		}
+	})
+
+	rec := h.WaitLogged(t, 5*time.Second, slog.LevelInfo, "tick", "i", 4)
+	assert.Equal(t, int64(4), rec.Attrs["i"].Int64())
+
+	wg.Wait()
+
+	// Records logged before waiting are also found.
+	rec = h.WaitLogged(t, time.Second, slog.LevelInfo, "tick", "i", 0)
+	assert.Equal(t, int64(0), rec.Attrs["i"].Int64())
+
+	ft := &fakeT{TB: t, errors: nil, fatal: false}
+	h.WaitLogged(ft, 10*time.Millisecond, slog.LevelInfo, "tock")
+	assert.True(t, ft.fatal)
+	require.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "no record matches INFO tock after 10ms")
+}
+
+func TestWaitFor(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := h.WaitFor(ctx, func(slogtest.Record) bool { return true })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHandlerWithSlogkit(t *testing.T) {
+	h := slogtest.NewHandler(nil)
+	logger := slog.New(slogkit.NewContextHandler(h))
+
+	logger.InfoContext(slogkit.WithAttrs(t.Context(), "request_id", "r1"), "handled")
+	h.AssertLogged(t, slog.LevelInfo, "handled", "request_id", "r1")
+}
+
+func TestConformance(t *testing.T) {
+	var h *slogtest.Handler
+
+	stdslogtest.Run(t, func(*testing.T) slog.Handler {
+		h = slogtest.NewHandler(nil)
+
+		return h
+	}, func(t *testing.T) map[string]any {
+		t.Helper()
+
+		records := h.Records()
+		require.Len(t, records, 1)
+
+		rec := records[0]
+		m := map[string]any{slog.LevelKey: rec.Level, slog.MessageKey: rec.Message}
+
+		if !rec.Time.IsZero() {
+			m[slog.TimeKey] = rec.Time
+		}
+
+		for key, v := range rec.Attrs {
+			parts := strings.Split(key, ".")
+
+			group := m
+			for _, name := range parts[:len(parts)-1] {
+				if _, ok := group[name].(map[string]any); !ok {
+					group[name] = map[string]any{}
+				}
+
+				group = group[name].(map[string]any) //nolint:forcetypeassert // Checked above.
+			}
+
+			group[parts[len(parts)-1]] = v.Any()
+		}
+
+		return m
+	})
+}
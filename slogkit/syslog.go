@@ -0,0 +1,389 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SyslogFacility is a syslog facility code.
+type SyslogFacility int
+
+// Syslog facilities as defined in RFC 5424.
+const (
+	SyslogKern SyslogFacility = iota
+	SyslogUser
+	SyslogMail
+	SyslogDaemon
+	SyslogAuth
+	SyslogSyslog
+	SyslogLPR
+	SyslogNews
+	SyslogUUCP
+	SyslogCron
+	SyslogAuthPriv
+	SyslogFTP
+	_
+	_
+	_
+	_
+	SyslogLocal0
+	SyslogLocal1
+	SyslogLocal2
+	SyslogLocal3
+	SyslogLocal4
+	SyslogLocal5
+	SyslogLocal6
+	SyslogLocal7
+)
+
+// SyslogSDID is the structured data ID of the element with the top-level attributes of
+// [HandlerSyslog] records. Top-level groups are rendered as elements with their name and the
+// same enterprise number, for example "http@32473".
+const SyslogSDID = "slog@32473"
+
+const (
+	syslogEnterprise    = "@32473"
+	syslogNilValue      = "-"
+	syslogMaxNameLen    = 32
+	syslogTimeLayout    = "2006-01-02T15:04:05.000000Z07:00"
+	syslog3164Layout    = time.Stamp
+	syslogMaxAppLen     = 48
+	syslogMaxHostLen    = 255
+	syslogMaxProcLen    = 128
+	syslogSeverityDebug = 7
+)
+
+// WithSyslogFacility sets the facility of [HandlerSyslog] records, [SyslogUser] by default.
+func WithSyslogFacility(facility SyslogFacility) Option {
+	return func(o *options) {
+		o.syslogFacility = facility
+	}
+}
+
+// WithSyslogAppName sets the application name of [HandlerSyslog] records and the
+// SYSLOG_IDENTIFIER field of [HandlerJournal] entries, by default the base name of the program.
+func WithSyslogAppName(name string) Option {
+	return func(o *options) {
+		o.syslogAppName = name
+	}
+}
+
+// WithSyslogRFC3164 makes [HandlerSyslog] use the legacy BSD syslog format of RFC 3164,
+// with attributes appended to the message as key=value pairs.
+func WithSyslogRFC3164() Option {
+	return func(o *options) {
+		o.syslogRFC3164 = true
+	}
+}
+
+// syslogSeverity returns the syslog severity of level.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= LevelFatal:
+		return 2 //nolint:mnd // Critical.
+	case level >= slog.LevelError:
+		return 3 //nolint:mnd // Error.
+	case level >= slog.LevelWarn:
+		return 4 //nolint:mnd // Warning.
+	case level >= LevelNotice:
+		return 5 //nolint:mnd // Notice.
+	case level >= slog.LevelInfo:
+		return 6 //nolint:mnd // Informational.
+	default:
+		return syslogSeverityDebug
+	}
+}
+
+// groupedAttr is an attribute added with WithAttrs together with its open groups.
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
+}
+
+// syslogHandler is an slog Handler that formats records as syslog messages.
+type syslogHandler struct {
+	writer  io.Writer
+	mu      *sync.Mutex
+	leveler slog.Leveler
+	o       *options
+	rep     ReplaceAttrFunc
+	header  string // HOSTNAME APP-NAME PROCID for RFC 5424, HOSTNAME TAG[PID]: for RFC 3164
+	attrs   []groupedAttr
+	groups  []string
+}
+
+// newSyslogHandler creates the slog Handler of [HandlerSyslog].
+func newSyslogHandler(writer io.Writer, leveler slog.Leveler, o *options) slog.Handler {
+	if leveler == nil {
+		leveler = slog.LevelInfo
+	}
+
+	host, _ := os.Hostname()
+	host = syslogField(host, syslogMaxHostLen)
+
+	app := o.syslogAppName
+	if app == "" {
+		app = filepath.Base(os.Args[0])
+	}
+
+	app = syslogField(app, syslogMaxAppLen)
+	pid := syslogField(strconv.Itoa(os.Getpid()), syslogMaxProcLen)
+
+	header := host + " " + app + " " + pid
+	if o.syslogRFC3164 {
+		header = host + " " + app + "[" + pid + "]:"
+	}
+
+	return &syslogHandler{
+		writer:  writer,
+		mu:      &sync.Mutex{},
+		leveler: leveler,
+		o:       o,
+		rep:     o.replaceAttr(),
+		header:  header,
+		attrs:   nil,
+		groups:  nil,
+	}
+}
+
+func (h *syslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.leveler.Level()
+}
+
+func (h *syslogHandler) Handle(_ context.Context, r slog.Record) error {
+	pri := int(h.o.syslogFacility)*8 + syslogSeverity(r.Level) //nolint:mnd // RFC 5424 PRI.
+
+	msg := r.Message
+	if a := h.rep(nil, slog.String(slog.MessageKey, msg)); a.Key != "" {
+		msg = a.Value.String()
+	}
+
+	sd := newSyslogSD()
+
+	if h.o.addSource && r.PC != 0 {
+		if a := h.rep(nil, slog.Any(slog.SourceKey, r.Source())); a.Key != "" {
+			if src, ok := a.Value.Any().(*slog.Source); ok {
+				a.Value = slog.StringValue(src.File + ":" + strconv.Itoa(src.Line))
+			}
+
+			sd.add(nil, a)
+		}
+	}
+
+	for _, ga := range h.attrs {
+		h.addAttr(sd, ga.groups, ga.attr)
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		h.addAttr(sd, h.groups, a)
+
+		return true
+	})
+
+	var b strings.Builder
+
+	b.WriteString("<" + strconv.Itoa(pri) + ">")
+
+	if h.o.syslogRFC3164 {
+		b.WriteString(r.Time.Format(syslog3164Layout) + " " + h.header + " " + msg)
+		sd.appendText(&b)
+	} else {
+		ts := syslogNilValue
+		if !r.Time.IsZero() {
+			ts = r.Time.Format(syslogTimeLayout)
+		}
+
+		b.WriteString("1 " + ts + " " + h.header + " " + syslogNilValue + " ")
+		sd.append5424(&b)
+
+		if msg != "" {
+			b.WriteString(" " + msg)
+		}
+	}
+
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.writer, b.String())
+
+	return err //nolint:wrapcheck // Transparent writer.
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, groupedAttr{groups: h.groups, attr: a})
+	}
+
+	return &h2
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
+}
+
+// addAttr adds a resolved attribute and its group members to sd applying the replacement chain.
+func (h *syslogHandler) addAttr(sd *syslogSD, groups []string, a slog.Attr) {
+	flattenAttr(h.rep, groups, a, sd.add)
+}
+
+// flattenAttr calls fn with each resolved non-group attribute in a, with its groups, after
+// applying rep. Attributes of groups with an empty key are inlined.
+func flattenAttr(rep ReplaceAttrFunc, groups []string, a slog.Attr, fn func([]string, slog.Attr)) {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		inner := groups
+		if a.Key != "" {
+			inner = append(slices.Clip(groups), a.Key)
+		}
+
+		for _, ga := range a.Value.Group() {
+			flattenAttr(rep, inner, ga, fn)
+		}
+
+		return
+	}
+
+	if a = rep(groups, a); a.Key == "" {
+		return
+	}
+
+	if a.Value = a.Value.Resolve(); a.Value.Kind() == slog.KindGroup {
+		flattenAttr(rep, groups, a, fn)
+
+		return
+	}
+
+	fn(groups, a)
+}
+
+// syslogSD collects structured data elements in order.
+type syslogSD struct {
+	ids    []string
+	params map[string][]string // SD-PARAM strings by SD-ID
+	text   []string            // key=value pairs for RFC 3164
+}
+
+func newSyslogSD() *syslogSD {
+	return &syslogSD{ids: nil, params: map[string][]string{}, text: nil}
+}
+
+// add adds a non-group attribute: top-level attributes go to SyslogSDID and attributes in groups
+// go to an element named after the first group, with the remaining groups as name prefix.
+func (sd *syslogSD) add(groups []string, a slog.Attr) {
+	id, name := SyslogSDID, a.Key
+	if len(groups) > 0 {
+		id = syslogName(groups[0], syslogMaxNameLen-len(syslogEnterprise)) + syslogEnterprise
+		name = strings.Join(append(slices.Clip(groups[1:]), a.Key), ".")
+	}
+
+	if _, ok := sd.params[id]; !ok {
+		sd.ids = append(sd.ids, id)
+	}
+
+	value := a.Value.String()
+	sd.params[id] = append(sd.params[id], syslogName(name, syslogMaxNameLen)+`="`+syslogEscape(value)+`"`)
+	sd.text = append(sd.text, strings.Join(append(slices.Clip(groups), a.Key), ".")+"="+strconv.Quote(value))
+}
+
+func (sd *syslogSD) append5424(b *strings.Builder) {
+	if len(sd.ids) == 0 {
+		b.WriteString(syslogNilValue)
+
+		return
+	}
+
+	for _, id := range sd.ids {
+		b.WriteString("[" + id)
+
+		for _, p := range sd.params[id] {
+			b.WriteString(" " + p)
+		}
+
+		b.WriteString("]")
+	}
+}
+
+func (sd *syslogSD) appendText(b *strings.Builder) {
+	for _, t := range sd.text {
+		b.WriteString(" " + t)
+	}
+}
+
+// syslogField returns s as a header field: printable US-ASCII without spaces, at most n long.
+func syslogField(s string, n int) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	if s == "" {
+		return syslogNilValue
+	}
+
+	return s[:min(len(s), n)]
+}
+
+// syslogName returns s as an SD-NAME: printable US-ASCII except '=', ' ', ']' and '"',
+// at most n long.
+func syslogName(s string, n int) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, s)
+
+	if s == "" {
+		return "_"
+	}
+
+	return s[:min(len(s), n)]
+}
+
+// syslogEscape escapes an SD-PARAM value.
+func syslogEscape(s string) string {
+	if !strings.ContainsAny(s, `"\]`) && utf8.ValidString(s) {
+		return s
+	}
+
+	var b strings.Builder
+
+	for _, r := range strings.ToValidUTF8(s, string(utf8.RuneError)) {
+		if r == '"' || r == '\\' || r == ']' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
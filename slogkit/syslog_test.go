@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerSyslog(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerSyslog, slogkit.LevelTrace,
+		slogkit.WithSyslogAppName("app"),
+	)
+	logger.With("app", "test").Info("message", "key", "val",
+		slog.Group("http", "status", 200, slog.Group("req", "path", "/")), slog.Group("", "inline", true))
+	logger.WithGroup("g").Warn("grouped", "quote", `a "b" \c]`)
+	logger.Error("")
+	logger.Debug("debug")
+	logger.Log(t.Context(), slogkit.LevelNotice, "notice")
+
+	pid := strconv.Itoa(os.Getpid())
+	want := regexp.MustCompile(`^<14>1 \S+ \S+ app ` + pid + ` - ` +
+		`\[slog@32473 app="test" key="val" inline="true"\]\[http@32473 status="200" req.path="/"\] message\n` +
+		`<12>1 \S+ \S+ app ` + pid + ` - \[g@32473 quote="a \\"b\\" \\\\c\\]"\] grouped\n` +
+		`<11>1 \S+ \S+ app ` + pid + ` - -\n` +
+		`<15>1 \S+ \S+ app ` + pid + ` - - debug\n` +
+		`<13>1 \S+ \S+ app ` + pid + ` - - notice\n$`)
+	assert.Regexp(t, want, buf.String())
+}
+
+func TestHandlerSyslogOptions(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerSyslog, slog.LevelInfo,
+		slogkit.WithSyslogAppName("my app"),
+		slogkit.WithSyslogFacility(slogkit.SyslogLocal3),
+		slogkit.WithSyslogRFC3164(),
+	)
+	logger.Info("message", "key", "a b", slog.Group("http", "status", 200))
+	logger.Log(t.Context(), slogkit.LevelFatal, "fatal")
+
+	pid := strconv.Itoa(os.Getpid())
+	want := regexp.MustCompile(`^<158>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2} \S+ myapp\[` + pid + `\]: ` +
+		`message key="a b" http.status="200"\n` +
+		`<154>.* myapp\[` + pid + `\]: fatal\n$`)
+	assert.Regexp(t, want, buf.String())
+}
+
+func TestSyslogWriterUnix(t *testing.T) {
+	addr := filepath.Join(t.TempDir(), "log.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	w, err := slogkit.NewSyslogWriter("", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	n, err := w.Write([]byte("<14>1 message\n"))
+	require.NoError(t, err)
+	assert.Equal(t, 14, n)
+
+	b := make([]byte, 1024)
+	n, err = conn.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "<14>1 message", string(b[:n]))
+}
+
+func TestSyslogWriterUnixStream(t *testing.T) {
+	addr := filepath.Join(t.TempDir(), "log.sock")
+
+	ln, err := net.Listen("unix", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	w, err := slogkit.NewSyslogWriter("", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = w.Write([]byte("<14>1 first\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("<14>1 second message"))
+	require.NoError(t, err)
+
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerSyslog, slog.LevelInfo, slogkit.WithSyslogAppName("app"))
+	logger.Error("panic\r\nrecovered", "stack", "goroutine 1 [running]:\nmain.main()\n")
+
+	// Local stream sockets use newline termination instead of octet counting,
+	// therefore line terminators within a message are escaped.
+	r := bufio.NewReader(conn)
+	assert.Equal(t, "<14>1 first\n<14>1 second message\n", readN(t, r, 33))
+
+	line, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Regexp(t, `^<11>1 \S+ \S+ app \d+ - `+
+		`\[slog@32473 stack="goroutine 1 \[running\\\]:\\nmain.main\(\)\\n"\] panic\\r\\nrecovered\n$`, line)
+}
+
+func TestSyslogWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	w, err := slogkit.NewSyslogWriter("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	logger := slogkit.NewLoggerWithOptions(w, slogkit.HandlerSyslog, slog.LevelInfo, slogkit.WithSyslogAppName("app"))
+	logger.Info("message", "key", "val")
+
+	b := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(b)
+	require.NoError(t, err)
+	assert.Regexp(t, `^<14>1 \S+ \S+ app \d+ - \[slog@32473 key="val"\] message$`, string(b[:n]))
+}
+
+func TestSyslogWriterTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	w, err := slogkit.NewSyslogWriter("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = w.Close() })
+
+	conn, err := ln.Accept()
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("second message"))
+	require.NoError(t, err)
+
+	r := bufio.NewReader(conn)
+	assert.Equal(t, "5 first", readN(t, r, 7))
+	assert.Equal(t, "14 second message", readN(t, r, 17))
+
+	// The server drops the connection: the writer reconnects once a write fails and sends again.
+	require.NoError(t, conn.Close())
+
+	accepted := make(chan net.Conn)
+
+	go func() {
+		c, _ := ln.Accept()
+		accepted <- c
+	}()
+
+	for conn = nil; conn == nil; {
+		_, err = w.Write([]byte("again"))
+		require.NoError(t, err)
+
+		select {
+		case conn = <-accepted:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	assert.Equal(t, "5 again", readN(t, bufio.NewReader(conn), 7))
+}
+
+func readN(t *testing.T, r io.Reader, n int) string {
+	t.Helper()
+
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	require.NoError(t, err)
+
+	return string(b)
+}
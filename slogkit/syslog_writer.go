@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultSyslogAddr is the address of the local syslog daemon socket.
+const DefaultSyslogAddr = "/dev/log"
+
+// SyslogWriter is an [io.Writer] that sends each write as a syslog message to a syslog daemon.
+// Use it with [HandlerSyslog], which formats each record in a single write.
+// Messages sent over TCP connections are framed using octet counting (RFC 6587) and messages
+// sent over local stream sockets are terminated with a newline, as expected by local daemons,
+// with newlines and carriage returns within them escaped as "\n" and "\r".
+// If sending a message fails, the connection is re-established once and the message is sent again.
+// It is safe for concurrent use.
+type SyslogWriter struct {
+	network string
+	addr    string
+	mu      sync.Mutex
+	conn    net.Conn
+	framing syslogFraming
+}
+
+// syslogLineEscaper escapes the line terminators within newline-terminated messages.
+//
+//nolint:gochecknoglobals // Read-only replacer.
+var syslogLineEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`)
+
+// syslogFraming is how messages are delimited on a syslog connection.
+type syslogFraming int
+
+const (
+	syslogFramingNone    syslogFraming = iota // one message per datagram
+	syslogFramingOctet                        // octet counting (RFC 6587)
+	syslogFramingNewline                      // newline termination
+)
+
+// NewSyslogWriter creates a [SyslogWriter] connected to the syslog daemon at addr.
+// The network can be "udp", "tcp" or "unix". With "unix" or an empty network, a datagram
+// connection is tried first and then a stream connection, and an empty addr means
+// [DefaultSyslogAddr].
+func NewSyslogWriter(network, addr string) (*SyslogWriter, error) {
+	if network == "" {
+		network = "unix"
+	}
+
+	if addr == "" && network == "unix" {
+		addr = DefaultSyslogAddr
+	}
+
+	w := &SyslogWriter{network: network, addr: addr, mu: sync.Mutex{}, conn: nil, framing: syslogFramingNone}
+	if err := w.dial(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write sends p as a single syslog message, without its trailing newline.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	msg := bytes.TrimSuffix(p, []byte("\n"))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		if err := w.dial(); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := w.send(msg); err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+
+		if err := w.dial(); err != nil {
+			return 0, err
+		}
+
+		if err := w.send(msg); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to the syslog daemon.
+func (w *SyslogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+
+	return err //nolint:wrapcheck // Transparent closer.
+}
+
+// dial connects to the syslog daemon.
+func (w *SyslogWriter) dial() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	switch w.network {
+	case "unix":
+		if conn, err = net.Dial("unixgram", w.addr); err != nil {
+			conn, err = net.Dial("unix", w.addr)
+		}
+
+		w.framing = syslogFramingNone
+		if conn != nil && conn.RemoteAddr().Network() == "unix" {
+			w.framing = syslogFramingNewline
+		}
+	case "udp", "udp4", "udp6", "unixgram", "unixpacket":
+		conn, err = net.Dial(w.network, w.addr)
+		w.framing = syslogFramingNone
+	default:
+		conn, err = net.Dial(w.network, w.addr)
+		w.framing = syslogFramingOctet
+	}
+
+	if err != nil {
+		return fmt.Errorf("syslog dial: %w", err)
+	}
+
+	w.conn = conn
+
+	return nil
+}
+
+// send writes msg to the connection, framed for streams.
+func (w *SyslogWriter) send(msg []byte) error {
+	switch w.framing {
+	case syslogFramingNone:
+	case syslogFramingOctet:
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	case syslogFramingNewline:
+		msg = append([]byte(syslogLineEscaper.Replace(string(msg))), '\n')
+	}
+
+	n, err := w.conn.Write(msg)
+	if err != nil {
+		return fmt.Errorf("syslog write: %w", err)
+	}
+
+	if n != len(msg) {
+		return io.ErrShortWrite
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lmittmann/tint"
+)
+
+// TintTheme configures the colors of [HandlerTint]. Colors are 8-bit ANSI colors:
+// 0-7 are the standard colors, 8-15 their high intensity variants, 16-231 a 6×6×6 color cube
+// and 232-255 a grayscale ramp. Elements without a color in the theme use the tint defaults.
+type TintTheme struct {
+	// Levels are the colors of level names by base level, for example [slog.LevelWarn] or
+	// [LevelNotice]. Levels between base levels, such as "WRN+1", use the lower base level color.
+	Levels map[slog.Level]uint8
+	// Keys are the colors of attributes by key, qualified with their groups (for example
+	// "http.status"). The key is rendered dimmed and the value in the color. The built-in time,
+	// message and source attributes can be colored using [slog.TimeKey], [slog.MessageKey] and
+	// [slog.SourceKey]; the time and source locations are always dimmed.
+	Keys map[string]uint8
+}
+
+// WithTintTheme sets the colors used by [HandlerTint].
+func WithTintTheme(theme TintTheme) Option {
+	return func(o *options) {
+		o.tintTheme = theme
+	}
+}
+
+// WithNoColor disables colors in [HandlerTint], keeping its compact format. See also
+// [DefaultAutoPolicy] for the environment variables that disable colors of [HandlerAuto].
+func WithNoColor(enabled bool) Option {
+	return func(o *options) {
+		o.noColor = enabled
+	}
+}
+
+// tintReplaceAttr adapts a replacement function to the tint handler, which renders the built-in
+// source attribute verbatim (instead of as "dir/file:line") whenever ReplaceAttr is set.
+// It also renders the custom slogkit levels and applies the theme colors.
+func tintReplaceAttr(fn ReplaceAttrFunc, theme TintTheme) ReplaceAttrFunc {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		key := a.Key
+		if len(groups) != 0 {
+			key = strings.Join(groups, ".") + "." + key
+		}
+
+		if a = fn(groups, a); a.Key == "" {
+			return a
+		}
+
+		if lvl, ok := builtinLevel(groups, a); ok {
+			return tintLevelAttr(a, lvl, theme)
+		}
+
+		if src, ok := a.Value.Any().(*slog.Source); ok && len(groups) == 0 && a.Value.Kind() == slog.KindAny {
+			dir, file := filepath.Split(src.File)
+			a.Value = slog.StringValue(filepath.Join(filepath.Base(dir), file) + ":" + strconv.Itoa(src.Line))
+		}
+
+		if c, ok := theme.Keys[key]; ok {
+			return tint.Attr(c, a)
+		}
+
+		return a
+	}
+}
+
+// tintLevelAttr renders custom levels for the tint handler with abbreviated colored names.
+// Standard levels are left untouched so that tint renders them, with the theme color if any.
+func tintLevelAttr(a slog.Attr, lvl slog.Level, theme TintTheme) slog.Attr {
+	base, offset := baseLevel(lvl)
+
+	c, ok := theme.Levels[base.level]
+	if !base.custom {
+		if ok {
+			return tint.Attr(c, a)
+		}
+
+		return a
+	}
+
+	if !ok {
+		c = base.color
+	}
+
+	return tint.Attr(c, slog.String(a.Key, appendOffset(base.short, offset)))
+}
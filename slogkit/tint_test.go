@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: Copyright 2023 Hugo Hromic
+// SPDX-License-Identifier: Apache-2.0
+
+package slogkit_test
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hhromic/go-toolkit/slogkit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTintTheme(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerTint, slogkit.LevelTrace,
+		slogkit.WithTimeLayout(time.DateOnly),
+		slogkit.WithTintTheme(slogkit.TintTheme{
+			Levels: map[slog.Level]uint8{slog.LevelWarn: 14, slogkit.LevelNotice: 10},
+			Keys:   map[string]uint8{slog.MessageKey: 5, slog.TimeKey: 4, "http.status": 9},
+		}),
+	)
+	logger.Warn("message", slog.Group("http", "status", 200), "key", "val")
+	logger.Log(t.Context(), slogkit.LevelNotice+1, "notice")
+	logger.Log(t.Context(), slogkit.LevelTrace, "trace")
+	logger.Info("info")
+
+	want := regexp.MustCompile(`^\x1b\[2;34m\d{4}-\d{2}-\d{2}\x1b\[0m \x1b\[96mWRN\x1b\[0m \x1b\[35mmessage\x1b\[0m ` +
+		`\x1b\[2;91mhttp.status=\x1b\[22m200\x1b\[0m \x1b\[2mkey=\x1b\[0mval\n` +
+		`.+ \x1b\[92mNTC\+1\x1b\[0m \x1b\[35mnotice\x1b\[0m\n` +
+		`.+ \x1b\[90mTRC\x1b\[0m \x1b\[35mtrace\x1b\[0m\n` +
+		`.+ \x1b\[92mINF\x1b\[0m \x1b\[35minfo\x1b\[0m\n$`)
+	assert.Regexp(t, want, buf.String())
+}
+
+func TestWithNoColor(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger := slogkit.NewLoggerWithOptions(&buf, slogkit.HandlerTint, slog.LevelInfo,
+		slogkit.WithNoColor(true),
+		slogkit.WithSource(true),
+		slogkit.WithTintTheme(slogkit.TintTheme{Levels: map[slog.Level]uint8{slog.LevelInfo: 1}, Keys: nil}),
+	)
+	logger.Info("message", "key", "val")
+	logger.Log(t.Context(), slogkit.LevelFatal, "fatal")
+
+	assert.Regexp(t, `^[^\x1b]+ INF slogkit/tint_test.go:\d+ message key=val\n[^\x1b]+ FTL slogkit/tint_test.go:\d+ fatal\n$`,
+		buf.String())
+}
+
+func TestReplaceAttrParity(t *testing.T) {
+	handlers := []slogkit.Handler{
+		slogkit.HandlerText, slogkit.HandlerJSON, slogkit.HandlerTint, slogkit.HandlerGCP,
+		slogkit.HandlerECS, slogkit.HandlerSyslog, slogkit.HandlerJournal,
+	}
+
+	for _, handler := range handlers {
+		t.Run(handler.String(), func(t *testing.T) {
+			var (
+				buf  bytes.Buffer
+				mu   sync.Mutex
+				seen []string
+			)
+
+			logger := slogkit.NewLoggerWithOptions(&buf, handler, slog.LevelInfo,
+				slogkit.WithSource(true),
+				slogkit.WithReplaceAttr(func(groups []string, a slog.Attr) slog.Attr {
+					mu.Lock()
+					seen = append(seen, a.Key)
+					mu.Unlock()
+
+					switch a.Key {
+					case "user":
+						a.Key = "usr"
+					case "secret":
+						return slog.Attr{}
+					}
+
+					return a
+				}),
+			)
+			logger.With("user", "john").WithGroup("g").Info("message", "secret", "hunter2", "key", "val")
+
+			assert.Contains(t, buf.String(), "john")
+			assert.NotContains(t, buf.String(), "hunter2")
+			assert.Regexp(t, `(?i)usr`, buf.String())
+
+			for _, key := range []string{slog.MessageKey, slog.SourceKey, "user", "secret", "key"} {
+				assert.True(t, slices.Contains(seen, key), "ReplaceAttr not called with %q", key)
+			}
+		})
+	}
+}